@@ -0,0 +1,29 @@
+// Command whatsapp-diag is a small CLI for exercising pieces of the
+// whatsapp package outside of a running session. It checks how a geo
+// URI or map link parses; it does not run attachment conversion.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"codeberg.org/slidge/slidge-whatsapp/whatsapp"
+)
+
+func main() {
+	geoURI := flag.String("geo", "", "parse a geo: URI or map link and print the resulting coordinates")
+	flag.Parse()
+
+	if *geoURI == "" {
+		fmt.Fprintln(os.Stderr, "usage: whatsapp-diag -geo <geo-uri-or-map-link>")
+		os.Exit(2)
+	}
+
+	loc, ok := whatsapp.DebugParseLocation(*geoURI)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "not a recognizable location")
+		os.Exit(1)
+	}
+	fmt.Printf("lat=%g lon=%g\n", loc.Latitude, loc.Longitude)
+}
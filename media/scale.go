@@ -0,0 +1,32 @@
+package media
+
+import "image"
+
+// scaleToFit returns img resized so its longest side is at most
+// maxDimension, using nearest-neighbor sampling (thumbnails don't
+// warrant a higher-quality, slower filter). Images already within
+// bounds are returned unchanged.
+func scaleToFit(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if maxDimension <= 0 || (width <= maxDimension && height <= maxDimension) {
+		return img
+	}
+
+	scale := float64(maxDimension) / float64(width)
+	if height > width {
+		scale = float64(maxDimension) / float64(height)
+	}
+	newWidth := int(float64(width) * scale)
+	newHeight := int(float64(height) * scale)
+
+	dst := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+	for y := 0; y < newHeight; y++ {
+		for x := 0; x < newWidth; x++ {
+			srcX := bounds.Min.X + x*width/newWidth
+			srcY := bounds.Min.Y + y*height/newHeight
+			dst.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,42 @@
+// Package media holds attachment conversion helpers shared by the
+// whatsapp package's send and receive paths.
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+// Thumbnailer produces small JPEG previews of image attachments, sized
+// to fit within a maximum dimension while preserving aspect ratio.
+type Thumbnailer struct {
+	MaxDimension int
+}
+
+// NewThumbnailer returns a Thumbnailer capping thumbnails at
+// maxDimension pixels on the longest side.
+func NewThumbnailer(maxDimension int) *Thumbnailer {
+	return &Thumbnailer{MaxDimension: maxDimension}
+}
+
+// Thumbnail decodes data as an image and returns a JPEG-encoded
+// thumbnail no larger than t.MaxDimension on its longest side.
+func (t *Thumbnailer) Thumbnail(data []byte) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("media: decode image: %w", err)
+	}
+
+	scaled := scaleToFit(img, t.MaxDimension)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, scaled, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("media: encode thumbnail: %w", err)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,41 @@
+package whatsapp
+
+import (
+	"fmt"
+	"time"
+)
+
+// rateLimitAckCode is the ack status code WhatsApp uses to reject an
+// operation for being sent too quickly, as opposed to any other
+// server-side rejection.
+const rateLimitAckCode = 429
+
+// defaultRateLimitRetryAfter is used when the server doesn't provide
+// its own retry hint alongside a rate-limit ack.
+const defaultRateLimitRetryAfter = 30 * time.Second
+
+// ServerAckError wraps a non-success acknowledgement WhatsApp's server
+// sent back for a revoke or edit request, since whatsmeow otherwise
+// only reports transport-level failures and silently drops server-side
+// rejections (e.g. editing a message too old to edit).
+type ServerAckError struct {
+	Operation string
+	MessageID string
+	Code      int
+}
+
+func (e *ServerAckError) Error() string {
+	return fmt.Sprintf("whatsapp: server rejected %s of message %s (code %d)", e.Operation, e.MessageID, e.Code)
+}
+
+// checkAck returns nil for a success code (0), a *RateLimitError for
+// rateLimitAckCode, and a *ServerAckError for any other code.
+func checkAck(operation, messageID string, code int) error {
+	if code == 0 {
+		return nil
+	}
+	if code == rateLimitAckCode {
+		return &RateLimitError{Operation: operation, RetryAfter: defaultRateLimitRetryAfter}
+	}
+	return &ServerAckError{Operation: operation, MessageID: messageID, Code: code}
+}
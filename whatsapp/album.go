@@ -0,0 +1,54 @@
+package whatsapp
+
+import "sync"
+
+// IncomingAlbum is a set of media messages WhatsApp grouped together as
+// a single album share.
+type IncomingAlbum struct {
+	Chat        string
+	AlbumID     string
+	Attachments []Attachment
+}
+
+// albumAggregator buffers album member messages until the album's
+// declared count of items has arrived, then emits a single
+// IncomingAlbum instead of one message per item.
+type albumAggregator struct {
+	mu      sync.Mutex
+	pending map[string]*pendingAlbum
+}
+
+type pendingAlbum struct {
+	album IncomingAlbum
+	want  int
+}
+
+func newAlbumAggregator() *albumAggregator {
+	return &albumAggregator{pending: make(map[string]*pendingAlbum)}
+}
+
+// start registers a new album expecting count attachments.
+func (a *albumAggregator) start(chat, albumID string, count int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending[albumID] = &pendingAlbum{album: IncomingAlbum{Chat: chat, AlbumID: albumID}, want: count}
+}
+
+// add appends attachment to albumID's pending set, returning the
+// completed album once it has received its declared count.
+func (a *albumAggregator) add(albumID string, attachment Attachment) (IncomingAlbum, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	p, ok := a.pending[albumID]
+	if !ok {
+		return IncomingAlbum{}, false
+	}
+	p.album.Attachments = append(p.album.Attachments, attachment)
+	if len(p.album.Attachments) < p.want {
+		return IncomingAlbum{}, false
+	}
+
+	delete(a.pending, albumID)
+	return p.album, true
+}
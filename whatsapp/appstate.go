@@ -0,0 +1,29 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/appstate"
+)
+
+// AppStateSyncFailedEvent is emitted when a requested app-state sync
+// (contacts, mute list, pin list, ...) fails, so the gateway can
+// surface a stale-roster warning instead of silently keeping outdated
+// data.
+type AppStateSyncFailedEvent struct {
+	Name appstate.WAPatchName
+	Err  error
+}
+
+// SyncAppState fetches and applies the named app-state patch (e.g.
+// appstate.WAPatchNameRegular for contacts), emitting
+// AppStateSyncFailedEvent if the fetch fails.
+func (s *Session) SyncAppState(ctx context.Context, name appstate.WAPatchName, fullSync bool) error {
+	if err := s.client.FetchAppState(ctx, name, fullSync, false); err != nil {
+		wrapped := fmt.Errorf("whatsapp: app state sync %s: %w", name, err)
+		s.handleEvent(&AppStateSyncFailedEvent{Name: name, Err: wrapped})
+		return wrapped
+	}
+	return nil
+}
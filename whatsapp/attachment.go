@@ -0,0 +1,24 @@
+package whatsapp
+
+// AttachmentKind identifies the media type of an Attachment. This is
+// the single source of truth for attachment media types; both the
+// outgoing SendRequest path and incoming message conversion share it
+// rather than keeping separate kind enums per direction.
+type AttachmentKind int
+
+const (
+	AttachmentKindNone AttachmentKind = iota
+	AttachmentKindImage
+	AttachmentKindVideo
+	AttachmentKindAudio
+	AttachmentKindDocument
+	AttachmentKindSticker
+)
+
+// Attachment is a single piece of media, sent or received.
+type Attachment struct {
+	Kind     AttachmentKind
+	Filename string
+	MIMEType string
+	Data     []byte
+}
@@ -0,0 +1,62 @@
+package whatsapp
+
+import (
+	"fmt"
+
+	"codeberg.org/slidge/slidge-whatsapp/media"
+)
+
+// convertAttachment is the session-aware counterpart of
+// ConvertAttachment: it reserves attachment.Data's size against the
+// session's memory budget for the duration of the conversion, so a
+// burst of large attachments can't be converted concurrently without
+// bound.
+func (s *Session) convertAttachment(attachment Attachment, profile ConversionProfile) (Attachment, error) {
+	size := int64(len(attachment.Data))
+	if err := s.attachmentMemory.reserve(size); err != nil {
+		return Attachment{}, err
+	}
+	defer s.attachmentMemory.release(size)
+
+	return ConvertAttachment(attachment, profile)
+}
+
+// ConvertAttachment applies this package's attachment conversion to
+// attachment: images are thumbnailed to JPEG, stickers are handed to
+// IncomingSticker.displayAttachment as animated (unless
+// profile.SkipStickerToGIF is set), and videos pass through once a
+// writable temp directory is confirmed. SendMessage and
+// incoming-message handling do not call it. Voice-note preprocessing
+// and waveform generation aren't part of this path since they need a
+// Session's ffmpeg runner; see Session.buildAudioAttachment.
+func ConvertAttachment(attachment Attachment, profile ConversionProfile) (Attachment, error) {
+	switch attachment.Kind {
+	case AttachmentKindImage:
+		dimension := defaultThumbnailDimension
+		if profile.MaxImageDimension > 0 {
+			dimension = profile.MaxImageDimension
+		}
+		thumb, err := media.NewThumbnailer(dimension).Thumbnail(attachment.Data)
+		if err != nil {
+			return Attachment{}, err
+		}
+		attachment.Data = thumb
+		attachment.MIMEType = "image/jpeg"
+		return attachment, nil
+
+	case AttachmentKindSticker:
+		if profile.SkipStickerToGIF {
+			return attachment, nil
+		}
+		return IncomingSticker{Data: attachment.Data, MIMEType: attachment.MIMEType, Animated: true}.displayAttachment(), nil
+
+	case AttachmentKindVideo:
+		if _, err := tempDir(); err != nil {
+			return Attachment{}, fmt.Errorf("whatsapp: video conversion needs a writable temp directory: %w", err)
+		}
+		return attachment, nil
+
+	default:
+		return attachment, nil
+	}
+}
@@ -0,0 +1,122 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+// mediaTypeFor maps an AttachmentKind to the whatsmeow media type its
+// upload must be tagged with, since the upload endpoint and resulting
+// encryption keys differ per media class.
+func mediaTypeFor(kind AttachmentKind) (whatsmeow.MediaType, error) {
+	switch kind {
+	case AttachmentKindImage, AttachmentKindSticker:
+		return whatsmeow.MediaImage, nil
+	case AttachmentKindVideo:
+		return whatsmeow.MediaVideo, nil
+	case AttachmentKindAudio:
+		return whatsmeow.MediaAudio, nil
+	case AttachmentKindDocument:
+		return whatsmeow.MediaDocument, nil
+	default:
+		return "", fmt.Errorf("whatsapp: no upload media type for attachment kind %d", kind)
+	}
+}
+
+// buildAttachmentMessage uploads req's first attachment to WhatsApp's
+// media servers and builds the message type matching its kind. Only
+// one attachment per message is supported, matching how WhatsApp's own
+// clients send media.
+func (s *Session) buildAttachmentMessage(ctx context.Context, req SendRequest) (*waE2E.Message, error) {
+	att := req.Attachments[0]
+
+	mediaType, err := mediaTypeFor(req.AttachmentKind)
+	if err != nil {
+		return nil, err
+	}
+
+	var audio AudioAttachment
+	if req.AttachmentKind == AttachmentKindAudio {
+		audio, err = s.buildAudioAttachment(att, req.Voice)
+		if err != nil {
+			return nil, fmt.Errorf("whatsapp: prepare voice note: %w", err)
+		}
+		att = audio.Attachment
+	}
+
+	uploaded, err := s.client.Upload(ctx, att.Data, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("whatsapp: upload attachment: %w", err)
+	}
+
+	switch req.AttachmentKind {
+	case AttachmentKindImage:
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      proto.String(att.MIMEType),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    proto.Uint64(uploaded.FileLength),
+			Caption:       proto.String(req.Body),
+		}}, nil
+
+	case AttachmentKindVideo:
+		return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      proto.String(att.MIMEType),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    proto.Uint64(uploaded.FileLength),
+			Caption:       proto.String(req.Body),
+		}}, nil
+
+	case AttachmentKindAudio:
+		return &waE2E.Message{AudioMessage: &waE2E.AudioMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      proto.String(att.MIMEType),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    proto.Uint64(uploaded.FileLength),
+			Seconds:       proto.Uint32(audio.Duration),
+			Waveform:      audio.Waveform,
+			PTT:           proto.Bool(audio.PTT),
+		}}, nil
+
+	case AttachmentKindDocument:
+		return &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      proto.String(att.MIMEType),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    proto.Uint64(uploaded.FileLength),
+			FileName:      proto.String(att.Filename),
+			Caption:       proto.String(req.Body),
+		}}, nil
+
+	case AttachmentKindSticker:
+		return &waE2E.Message{StickerMessage: &waE2E.StickerMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			Mimetype:      proto.String(att.MIMEType),
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    proto.Uint64(uploaded.FileLength),
+		}}, nil
+
+	default:
+		return nil, fmt.Errorf("whatsapp: unsupported attachment kind %d", req.AttachmentKind)
+	}
+}
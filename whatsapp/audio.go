@@ -0,0 +1,199 @@
+package whatsapp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VoicePreprocessing describes speed and trim adjustments to apply to
+// an outgoing voice note before upload.
+type VoicePreprocessing struct {
+	// Speed scales playback rate; 1.0 leaves it unchanged.
+	Speed float64
+	// TrimStart is the position in the original clip the output starts
+	// at; TrimEnd, when non-zero, is the position it stops at.
+	TrimStart, TrimEnd time.Duration
+}
+
+// Validate checks p for a usable speed/trim combination.
+func (p VoicePreprocessing) Validate() error {
+	if p.Speed < 0 {
+		return fmt.Errorf("whatsapp: voice preprocessing speed must not be negative")
+	}
+	if p.TrimStart < 0 || p.TrimEnd < 0 {
+		return fmt.Errorf("whatsapp: voice preprocessing trim must not be negative")
+	}
+	return nil
+}
+
+// AudioAttachment is a voice note or audio file, with the metadata
+// needed to render a duration/scrubber in XMPP clients that support
+// it.
+type AudioAttachment struct {
+	Attachment
+	Duration uint32
+	Waveform []byte
+	PTT      bool
+}
+
+// applyVoicePreprocessing re-encodes data through ffmpeg according to
+// prep's speed/trim settings, returning data unchanged if prep is nil
+// or a zero value. Speed is applied with ffmpeg's atempo filter; trim
+// is applied with -ss/-to placed after the input, as output options,
+// so the input is decoded and the cut made on the decoded audio.
+func (s *Session) applyVoicePreprocessing(data []byte, prep *VoicePreprocessing) ([]byte, error) {
+	if prep == nil || (prep.Speed == 0 || prep.Speed == 1) && prep.TrimStart == 0 && prep.TrimEnd == 0 {
+		return data, nil
+	}
+	if err := prep.Validate(); err != nil {
+		return nil, err
+	}
+
+	args := []string{"-i", "pipe:0"}
+	if prep.TrimStart > 0 {
+		args = append(args, "-ss", formatSeconds(prep.TrimStart))
+	}
+	if prep.TrimEnd > 0 {
+		args = append(args, "-to", formatSeconds(prep.TrimEnd))
+	}
+	if prep.Speed != 0 && prep.Speed != 1 {
+		args = append(args, "-filter:a", "atempo="+strconv.FormatFloat(prep.Speed, 'f', -1, 64))
+	}
+	args = append(args, "-f", "ogg", "-c:a", "libopus", "pipe:1")
+
+	out, err := s.ffmpeg.ffmpeg(args, data)
+	if err != nil {
+		return nil, fmt.Errorf("whatsapp: apply voice preprocessing: %w", err)
+	}
+	return out, nil
+}
+
+// formatSeconds renders d as a decimal-seconds string suitable for
+// ffmpeg's -ss/-to flags.
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// buildAudioAttachment applies voice to att.Data (if requested) and
+// computes the waveform/duration metadata WhatsApp voice notes carry,
+// returning an AudioAttachment ready for upload.
+func (s *Session) buildAudioAttachment(att Attachment, voice *VoicePreprocessing) (AudioAttachment, error) {
+	data, err := s.applyVoicePreprocessing(att.Data, voice)
+	if err != nil {
+		return AudioAttachment{}, err
+	}
+	att.Data = data
+
+	waveform, err := s.generateWaveform(data)
+	if err != nil {
+		return AudioAttachment{}, err
+	}
+
+	duration, err := s.correctedDuration(data)
+	if err != nil {
+		return AudioAttachment{}, err
+	}
+
+	return AudioAttachment{
+		Attachment: att,
+		Duration:   duration,
+		Waveform:   waveform,
+		PTT:        true,
+	}, nil
+}
+
+// waveformSampleCount is how many amplitude samples WhatsApp expects
+// in a voice note's waveform preview.
+const waveformSampleCount = 64
+
+// generateWaveform decodes raw PCM samples for data via s.ffmpeg (piped
+// through stdin/stdout, so nothing touches disk) and downsamples them
+// into at most waveformSampleCount peak-amplitude buckets scaled to a
+// byte. Each bucket spans frameCount/waveformSampleCount frames (at
+// least one), so input with fewer frames than that yields one bucket
+// per frame; frames past the last bucket are not included. Empty input
+// yields waveformSampleCount zero bytes.
+func (s *Session) generateWaveform(data []byte) ([]byte, error) {
+	pcm, err := s.ffmpeg.ffmpeg([]string{"-i", "pipe:0", "-f", "s16le", "-ac", "1", "-ar", "8000", "pipe:1"}, data)
+	if err != nil {
+		return nil, fmt.Errorf("whatsapp: decode audio for waveform: %w", err)
+	}
+
+	if len(pcm) < 2 {
+		return make([]byte, waveformSampleCount), nil
+	}
+
+	frameCount := len(pcm) / 2
+	bucketSize := frameCount / waveformSampleCount
+	if bucketSize == 0 {
+		bucketSize = 1
+	}
+
+	waveform := make([]byte, 0, waveformSampleCount)
+	for i := 0; i < frameCount && len(waveform) < waveformSampleCount; i += bucketSize {
+		var peak int16
+		for j := i; j < i+bucketSize && j < frameCount; j++ {
+			sample := int16(pcm[j*2]) | int16(pcm[j*2+1])<<8
+			if sample < 0 {
+				sample = -sample
+			}
+			if sample > peak {
+				peak = sample
+			}
+		}
+		waveform = append(waveform, byte(peak>>8))
+	}
+	return waveform, nil
+}
+
+// audioStreamSampleRate is the sample rate streamDuration decodes at;
+// it only needs to be fast and consistent, not high fidelity, since the
+// result is used for a frame count rather than played back.
+const audioStreamSampleRate = 8000
+
+// containerDuration reads data's own duration metadata via ffprobe.
+func (s *Session) containerDuration(data []byte) (uint32, error) {
+	out, err := s.ffmpeg.ffprobe([]string{"-v", "error", "-show_entries", "format=duration", "-of", "default=nw=1:nk=1", "pipe:0"}, data)
+	if err != nil {
+		return 0, fmt.Errorf("whatsapp: probe container duration: %w", err)
+	}
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("whatsapp: parse container duration %q: %w", out, err)
+	}
+	return uint32(seconds + 0.5), nil
+}
+
+// streamDuration decodes data's audio and counts elapsed time directly,
+// since some encoders (and some third-party clients that concatenate
+// voice notes) write a container duration header that undercounts the
+// actual decoded length.
+func (s *Session) streamDuration(data []byte) (uint32, error) {
+	pcm, err := s.ffmpeg.ffmpeg([]string{"-i", "pipe:0", "-f", "s16le", "-ac", "1", "-ar", strconv.Itoa(audioStreamSampleRate), "pipe:1"}, data)
+	if err != nil {
+		return 0, fmt.Errorf("whatsapp: decode audio for duration: %w", err)
+	}
+	frames := len(pcm) / 2
+	return uint32(frames / audioStreamSampleRate), nil
+}
+
+// correctedDuration cross-checks data's container duration against its
+// decode-time length and returns the larger of the two, so a message's
+// reported duration doesn't undercount audio whose container header is
+// wrong.
+func (s *Session) correctedDuration(data []byte) (uint32, error) {
+	container, err := s.containerDuration(data)
+	if err != nil {
+		return 0, err
+	}
+	stream, err := s.streamDuration(data)
+	if err != nil {
+		return 0, err
+	}
+	if stream > container {
+		return stream, nil
+	}
+	return container, nil
+}
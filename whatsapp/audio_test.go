@@ -0,0 +1,193 @@
+package whatsapp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// pcmS16LE encodes samples as little-endian 16-bit PCM, matching what
+// ffmpeg is asked to emit on stdout.
+func pcmS16LE(samples ...int16) []byte {
+	out := make([]byte, 0, len(samples)*2)
+	for _, s := range samples {
+		out = append(out, byte(s), byte(s>>8))
+	}
+	return out
+}
+
+func TestGenerateWaveformDownsamplesPeaks(t *testing.T) {
+	fake := &fakeFFmpegRunner{ffmpegOutput: pcmS16LE(100, -200, 300, -50)}
+	s := &Session{ffmpeg: fake}
+
+	waveform, err := s.generateWaveform([]byte("input audio bytes"))
+	if err != nil {
+		t.Fatalf("generateWaveform: %v", err)
+	}
+	if len(waveform) != 4 {
+		t.Fatalf("waveform length = %d, want 4 (one bucket per sample)", len(waveform))
+	}
+
+	want := []byte{byte(int16(100) >> 8), byte(int16(200) >> 8), byte(int16(300) >> 8), byte(int16(50) >> 8)}
+	for i, b := range want {
+		if waveform[i] != b {
+			t.Errorf("waveform[%d] = %d, want %d", i, waveform[i], b)
+		}
+	}
+
+	if len(fake.calls) != 1 || fake.calls[0].tool != "ffmpeg" {
+		t.Fatalf("expected exactly one ffmpeg call, got %+v", fake.calls)
+	}
+}
+
+func TestGenerateWaveformEmptyPCMReturnsSilence(t *testing.T) {
+	fake := &fakeFFmpegRunner{ffmpegOutput: nil}
+	s := &Session{ffmpeg: fake}
+
+	waveform, err := s.generateWaveform([]byte("short"))
+	if err != nil {
+		t.Fatalf("generateWaveform: %v", err)
+	}
+	if len(waveform) != waveformSampleCount {
+		t.Fatalf("waveform length = %d, want %d", len(waveform), waveformSampleCount)
+	}
+	for i, b := range waveform {
+		if b != 0 {
+			t.Fatalf("waveform[%d] = %d, want 0 for silent input", i, b)
+		}
+	}
+}
+
+func TestGenerateWaveformPropagatesFFmpegError(t *testing.T) {
+	fake := &fakeFFmpegRunner{ffmpegErr: errors.New("boom")}
+	s := &Session{ffmpeg: fake}
+
+	if _, err := s.generateWaveform([]byte("data")); err == nil {
+		t.Fatal("expected an error when ffmpeg fails, got nil")
+	}
+}
+
+func TestCorrectedDurationPrefersLargerStreamDuration(t *testing.T) {
+	// Container header claims 3s; the decoded stream is actually 5s
+	// (40000 frames at 8000Hz), so the decode-time count should win.
+	fake := &fakeFFmpegRunner{
+		ffprobeOutput: []byte("3.000000\n"),
+		ffmpegOutput:  pcmS16LE(make([]int16, 40000)...),
+	}
+	s := &Session{ffmpeg: fake}
+
+	got, err := s.correctedDuration([]byte("audio"))
+	if err != nil {
+		t.Fatalf("correctedDuration: %v", err)
+	}
+	if got != 5 {
+		t.Fatalf("correctedDuration = %d, want 5 (decode-time count wins over container header)", got)
+	}
+}
+
+func TestCorrectedDurationPrefersLargerContainerDuration(t *testing.T) {
+	fake := &fakeFFmpegRunner{
+		ffprobeOutput: []byte("10.000000\n"),
+		ffmpegOutput:  pcmS16LE(make([]int16, 8000)...), // 1s of decoded audio
+	}
+	s := &Session{ffmpeg: fake}
+
+	got, err := s.correctedDuration([]byte("audio"))
+	if err != nil {
+		t.Fatalf("correctedDuration: %v", err)
+	}
+	if got != 10 {
+		t.Fatalf("correctedDuration = %d, want 10 (container header wins over a short decode)", got)
+	}
+}
+
+func TestContainerDurationRejectsUnparsableOutput(t *testing.T) {
+	fake := &fakeFFmpegRunner{ffprobeOutput: []byte("not a number")}
+	s := &Session{ffmpeg: fake}
+
+	if _, err := s.containerDuration([]byte("audio")); err == nil {
+		t.Fatal("expected an error for unparsable ffprobe output, got nil")
+	}
+}
+
+func TestApplyVoicePreprocessingNilIsNoop(t *testing.T) {
+	fake := &fakeFFmpegRunner{}
+	s := &Session{ffmpeg: fake}
+
+	out, err := s.applyVoicePreprocessing([]byte("original"), nil)
+	if err != nil {
+		t.Fatalf("applyVoicePreprocessing: %v", err)
+	}
+	if string(out) != "original" {
+		t.Fatalf("applyVoicePreprocessing = %q, want input unchanged", out)
+	}
+	if len(fake.calls) != 0 {
+		t.Fatalf("expected no ffmpeg calls for nil preprocessing, got %+v", fake.calls)
+	}
+}
+
+func TestApplyVoicePreprocessingUnitSpeedIsNoop(t *testing.T) {
+	fake := &fakeFFmpegRunner{}
+	s := &Session{ffmpeg: fake}
+
+	out, err := s.applyVoicePreprocessing([]byte("original"), &VoicePreprocessing{Speed: 1})
+	if err != nil {
+		t.Fatalf("applyVoicePreprocessing: %v", err)
+	}
+	if string(out) != "original" {
+		t.Fatalf("applyVoicePreprocessing = %q, want input unchanged", out)
+	}
+	if len(fake.calls) != 0 {
+		t.Fatalf("expected no ffmpeg calls for a no-op speed/trim, got %+v", fake.calls)
+	}
+}
+
+func TestApplyVoicePreprocessingRejectsInvalid(t *testing.T) {
+	s := &Session{ffmpeg: &fakeFFmpegRunner{}}
+
+	if _, err := s.applyVoicePreprocessing([]byte("audio"), &VoicePreprocessing{Speed: -1}); err == nil {
+		t.Fatal("expected an error for a negative speed, got nil")
+	}
+}
+
+func TestApplyVoicePreprocessingRunsFFmpegForSpeedAndTrim(t *testing.T) {
+	fake := &fakeFFmpegRunner{ffmpegOutput: []byte("processed")}
+	s := &Session{ffmpeg: fake}
+
+	out, err := s.applyVoicePreprocessing([]byte("original"), &VoicePreprocessing{
+		Speed:     1.5,
+		TrimStart: 2 * time.Second,
+		TrimEnd:   4 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("applyVoicePreprocessing: %v", err)
+	}
+	if string(out) != "processed" {
+		t.Fatalf("applyVoicePreprocessing = %q, want ffmpeg output", out)
+	}
+	if len(fake.calls) != 1 || fake.calls[0].tool != "ffmpeg" {
+		t.Fatalf("expected exactly one ffmpeg call, got %+v", fake.calls)
+	}
+}
+
+func TestBuildAudioAttachmentSetsWaveformDurationAndPTT(t *testing.T) {
+	fake := &fakeFFmpegRunner{
+		ffmpegOutput:  pcmS16LE(make([]int16, 8000)...),
+		ffprobeOutput: []byte("1.000000\n"),
+	}
+	s := &Session{ffmpeg: fake}
+
+	att, err := s.buildAudioAttachment(Attachment{Kind: AttachmentKindAudio, Data: []byte("audio")}, nil)
+	if err != nil {
+		t.Fatalf("buildAudioAttachment: %v", err)
+	}
+	if !att.PTT {
+		t.Fatal("expected PTT to be set for a voice note")
+	}
+	if att.Duration != 1 {
+		t.Fatalf("Duration = %d, want 1", att.Duration)
+	}
+	if len(att.Waveform) != waveformSampleCount {
+		t.Fatalf("Waveform length = %d, want %d", len(att.Waveform), waveformSampleCount)
+	}
+}
@@ -0,0 +1,49 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// StoreBackupFunc copies the session's device store to w, e.g. by
+// dumping the underlying SQLite file.
+type StoreBackupFunc func(w io.Writer) error
+
+// StartDailyStoreBackup runs backup once every 24 hours until ctx is
+// canceled, writing each backup via newWriter. It runs in its own
+// goroutine and returns immediately.
+func (s *Session) StartDailyStoreBackup(ctx context.Context, backup StoreBackupFunc, newWriter func() (io.WriteCloser, error)) {
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runStoreBackup(backup, newWriter)
+			}
+		}
+	}()
+}
+
+func (s *Session) runStoreBackup(backup StoreBackupFunc, newWriter func() (io.WriteCloser, error)) {
+	w, err := newWriter()
+	if err != nil {
+		s.handleEvent(&StoreBackupFailedEvent{Err: fmt.Errorf("whatsapp: open backup destination: %w", err)})
+		return
+	}
+	defer w.Close()
+
+	if err := backup(w); err != nil {
+		s.handleEvent(&StoreBackupFailedEvent{Err: fmt.Errorf("whatsapp: backup store: %w", err)})
+	}
+}
+
+// StoreBackupFailedEvent is emitted when a scheduled store backup
+// fails, so the gateway can alert instead of silently missing backups.
+type StoreBackupFailedEvent struct {
+	Err error
+}
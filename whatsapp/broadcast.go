@@ -0,0 +1,40 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// BroadcastList is a WhatsApp broadcast list: a named set of recipients
+// a message is sent to individually, without a shared group chat.
+type BroadcastList struct {
+	JID        string
+	Name       string
+	Recipients []string
+}
+
+// GetBroadcastListInfo fetches a broadcast list's recipients. Name is
+// left empty: WhatsApp's multi-device servers don't sync broadcast list
+// names, only their membership.
+func (s *Session) GetBroadcastListInfo(ctx context.Context, listJID string) (BroadcastList, error) {
+	target, err := jid(listJID)
+	if err != nil {
+		return BroadcastList{}, err
+	}
+	if target.Server != types.BroadcastServer {
+		return BroadcastList{}, fmt.Errorf("whatsapp: %s is not a broadcast list JID", listJID)
+	}
+
+	participants, err := s.client.DangerousInternals().GetBroadcastListParticipants(ctx, target)
+	if err != nil {
+		return BroadcastList{}, fmt.Errorf("whatsapp: GetBroadcastListParticipants: %w", err)
+	}
+
+	list := BroadcastList{JID: target.String()}
+	for _, r := range participants {
+		list.Recipients = append(list.Recipients, r.String())
+	}
+	return list, nil
+}
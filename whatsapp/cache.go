@@ -0,0 +1,88 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// warmCache holds contact and group metadata fetched during this or a
+// previous session, so a freshly-started Session can answer roster
+// queries immediately instead of waiting on a round trip (or an app
+// state sync) for every chat before anything can be bridged.
+type warmCache struct {
+	mu       sync.RWMutex
+	Contacts map[string]Contact `json:"contacts"`
+	Groups   map[string]Group   `json:"groups"`
+}
+
+func newWarmCache() *warmCache {
+	return &warmCache{
+		Contacts: make(map[string]Contact),
+		Groups:   make(map[string]Group),
+	}
+}
+
+func (c *warmCache) putContact(contact Contact) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Contacts[contact.JID] = contact
+}
+
+func (c *warmCache) putGroup(group Group) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Groups[group.JID] = group
+}
+
+func (c *warmCache) getGroup(jid string) (Group, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	group, ok := c.Groups[jid]
+	return group, ok
+}
+
+func (c *warmCache) getContact(jid string) (Contact, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	contact, ok := c.Contacts[jid]
+	return contact, ok
+}
+
+// saveTo serializes the cache to w as JSON.
+func (c *warmCache) saveTo(w io.Writer) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return json.NewEncoder(w).Encode(c)
+}
+
+// loadFrom replaces the cache's contents with what's decoded from r.
+func (c *warmCache) loadFrom(r io.Reader) error {
+	var loaded warmCache
+	if err := json.NewDecoder(r).Decode(&loaded); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if loaded.Contacts == nil {
+		loaded.Contacts = make(map[string]Contact)
+	}
+	if loaded.Groups == nil {
+		loaded.Groups = make(map[string]Group)
+	}
+	c.Contacts = loaded.Contacts
+	c.Groups = loaded.Groups
+	return nil
+}
+
+// SaveCache persists the session's warm cache to w, for reloading on
+// the next startup via LoadCache.
+func (s *Session) SaveCache(w io.Writer) error {
+	return s.cache.saveTo(w)
+}
+
+// LoadCache replaces the session's warm cache with what's read from r,
+// typically the file a previous session wrote via SaveCache.
+func (s *Session) LoadCache(r io.Reader) error {
+	return s.cache.loadFrom(r)
+}
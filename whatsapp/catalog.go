@@ -0,0 +1,78 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BusinessProfile is the subset of a WhatsApp Business account's
+// public profile the gateway can surface, e.g. as vCard-adjacent
+// metadata on the corresponding XMPP roster entry.
+type BusinessProfile struct {
+	JID         string
+	Email       string
+	Address     string
+	Categories  []string
+	Description string
+	Websites    []string
+}
+
+// GetBusinessProfile fetches business profile details for id, which
+// must be a verified business account; ordinary contacts return an
+// error.
+func (s *Session) GetBusinessProfile(ctx context.Context, id string) (BusinessProfile, error) {
+	target, err := jid(id)
+	if err != nil {
+		return BusinessProfile{}, err
+	}
+
+	profile, err := s.client.GetBusinessProfile(ctx, target)
+	if err != nil {
+		return BusinessProfile{}, fmt.Errorf("whatsapp: GetBusinessProfile: %w", err)
+	}
+	if profile == nil {
+		return BusinessProfile{}, fmt.Errorf("whatsapp: %s has no business profile", id)
+	}
+
+	categories := make([]string, 0, len(profile.Categories))
+	for _, c := range profile.Categories {
+		categories = append(categories, c.Name)
+	}
+
+	var websites []string
+	for key, value := range profile.ProfileOptions {
+		if strings.HasPrefix(key, "website") {
+			websites = append(websites, value)
+		}
+	}
+	sort.Strings(websites)
+
+	return BusinessProfile{
+		JID:         normalizeJID(target).String(),
+		Email:       profile.Email,
+		Address:     profile.Address,
+		Categories:  categories,
+		Description: profile.ProfileOptions["description"],
+		Websites:    websites,
+	}, nil
+}
+
+// Product is a single item in a business's catalog, as shared via a
+// product message.
+type Product struct {
+	ID          string
+	Name        string
+	Description string
+	Price       int64
+	Currency    string
+	ImageURL    string
+}
+
+// ProductMessage is an incoming share of one catalog item.
+type ProductMessage struct {
+	MessageID string
+	From      string
+	Product   Product
+}
@@ -0,0 +1,69 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Channel is a WhatsApp Channel (newsletter): a one-way broadcast feed
+// that accounts follow.
+type Channel struct {
+	JID         string
+	Name        string
+	Description string
+}
+
+// FollowChannel subscribes to the channel identified by channelJID.
+func (s *Session) FollowChannel(ctx context.Context, channelJID string) (Channel, error) {
+	target, err := jid(channelJID)
+	if err != nil {
+		return Channel{}, err
+	}
+	if target.Server != types.NewsletterServer {
+		return Channel{}, fmt.Errorf("whatsapp: %s is not a channel JID", channelJID)
+	}
+
+	info, err := s.client.GetNewsletterInfo(ctx, target)
+	if err != nil {
+		return Channel{}, fmt.Errorf("whatsapp: GetNewsletterInfo: %w", err)
+	}
+	if err := s.client.FollowNewsletter(ctx, target); err != nil {
+		return Channel{}, fmt.Errorf("whatsapp: FollowNewsletter: %w", err)
+	}
+
+	return Channel{JID: target.String(), Name: info.ThreadMeta.Name.Text, Description: info.ThreadMeta.Description.Text}, nil
+}
+
+// SendChannelMessage posts body to a channel we own. It's a thin
+// wrapper over Session.SendMessage, which dispatches to any JID
+// (including a newsletter's) the same way; this just adds the
+// channel-specific validation.
+func (s *Session) SendChannelMessage(ctx context.Context, channelJID, body string) (string, error) {
+	target, err := jid(channelJID)
+	if err != nil {
+		return "", err
+	}
+	if target.Server != types.NewsletterServer {
+		return "", fmt.Errorf("whatsapp: %s is not a channel JID", channelJID)
+	}
+	if body == "" {
+		return "", fmt.Errorf("whatsapp: channel message requires a body")
+	}
+
+	return s.SendMessage(ctx, SendRequest{Chat: channelJID, Body: body})
+}
+
+// UnfollowChannel unsubscribes from the channel identified by
+// channelJID.
+func (s *Session) UnfollowChannel(ctx context.Context, channelJID string) error {
+	target, err := jid(channelJID)
+	if err != nil {
+		return err
+	}
+	if err := s.client.UnfollowNewsletter(ctx, target); err != nil {
+		return fmt.Errorf("whatsapp: UnfollowNewsletter: %w", err)
+	}
+	return nil
+}
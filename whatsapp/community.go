@@ -0,0 +1,23 @@
+package whatsapp
+
+// CommunityLinkChangedEvent is emitted when a group is linked to, or
+// unlinked from, a WhatsApp community, so the gateway can reparent (or
+// unparent) the corresponding bridged chat in its own roster.
+type CommunityLinkChangedEvent struct {
+	Group     string
+	Community string
+	Linked    bool
+}
+
+// handleCommunityLinkChange translates a group-info delta's community
+// link/unlink lists into one CommunityLinkChangedEvent per affected
+// group, mirroring how handleGroupInfoEvent handles participant
+// changes.
+func (s *Session) handleCommunityLinkChange(community string, linked, unlinked []string) {
+	for _, group := range linked {
+		s.handleEvent(&CommunityLinkChangedEvent{Group: group, Community: community, Linked: true})
+	}
+	for _, group := range unlinked {
+		s.handleEvent(&CommunityLinkChangedEvent{Group: group, Community: community, Linked: false})
+	}
+}
@@ -0,0 +1,79 @@
+package whatsapp
+
+import "time"
+
+// SelfMessageMode controls how messages sent to our own JID ("Message
+// Yourself") are handled.
+type SelfMessageMode int
+
+const (
+	// SelfMessageModeBridge relays self-messages like any other chat.
+	SelfMessageModeBridge SelfMessageMode = iota
+	// SelfMessageModeIgnore drops self-messages entirely.
+	SelfMessageModeIgnore
+)
+
+// Config holds per-session behavioral options that don't belong on a
+// single call but tune how Session handles ambiguous cases.
+type Config struct {
+	SelfMessageMode SelfMessageMode
+
+	// SuppressBotChats drops messages from Meta AI (metaAIJID) instead
+	// of bridging them. No other bot accounts are recognized.
+	SuppressBotChats bool
+
+	// Location is the time zone timestamps are rendered in for this
+	// session. Defaults to UTC if nil.
+	Location *time.Location
+
+	// ReactionFallbackToReceipt emits incoming reactions as
+	// ReactionReceiptEvent read receipts instead of IncomingReaction,
+	// for XMPP clients that don't support XEP-0444 message reactions.
+	ReactionFallbackToReceipt bool
+
+	// CarbonSuppressionWindow bounds how long after we send a message
+	// its own-device echo is suppressed rather than re-bridged as a
+	// carbon copy. Defaults to defaultCarbonSuppressionWindow if zero.
+	CarbonSuppressionWindow time.Duration
+}
+
+// defaultCarbonSuppressionWindow covers ordinary round-trip latency for
+// our own message's echo to arrive back from the server.
+const defaultCarbonSuppressionWindow = 10 * time.Second
+
+// carbonSuppressionWindow returns the session's configured window,
+// falling back to the default when unset.
+func (s *Session) carbonSuppressionWindow() time.Duration {
+	if s.config.CarbonSuppressionWindow > 0 {
+		return s.config.CarbonSuppressionWindow
+	}
+	return defaultCarbonSuppressionWindow
+}
+
+// isRecentOwnCarbon reports whether messageID was sent by us within the
+// session's carbon suppression window, meaning its echo should be
+// dropped rather than re-bridged.
+func (s *Session) isRecentOwnCarbon(messageID string) bool {
+	sentAt, ok := s.sentMessages.sentAt(messageID)
+	if !ok {
+		return false
+	}
+	return time.Since(sentAt) <= s.carbonSuppressionWindow()
+}
+
+// timestampIn renders t in the session's configured time zone.
+func (s *Session) timestampIn(t time.Time) time.Time {
+	if s.config.Location == nil {
+		return t.UTC()
+	}
+	return t.In(s.config.Location)
+}
+
+// metaAIJID is Meta AI's well-known WhatsApp JID.
+const metaAIJID = "13135550002@s.whatsapp.net"
+
+// isSuppressedBotChat reports whether sender should be dropped under
+// the session's SuppressBotChats setting.
+func (s *Session) isSuppressedBotChat(sender string) bool {
+	return s.config.SuppressBotChats && sender == metaAIJID
+}
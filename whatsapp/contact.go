@@ -0,0 +1,86 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Contact is the subset of WhatsApp contact information the gateway
+// cares about.
+type Contact struct {
+	JID   string
+	Name  string
+	Found bool
+
+	// VerifiedName is the business-verified display name returned by
+	// WhatsApp for confirmed business accounts, empty otherwise.
+	VerifiedName string
+	// IsBusiness reports whether the contact is a verified business
+	// account.
+	IsBusiness bool
+}
+
+// FindContact resolves id (a bare phone number or full JID) to a
+// Contact, querying WhatsApp for whether the number is registered and,
+// for business accounts, its verified name. A positive result is warm
+// cached, since registration status rarely changes; a negative result
+// is not, so a number that later joins WhatsApp is found promptly.
+func (s *Session) FindContact(ctx context.Context, id string) (Contact, error) {
+	target, err := jid(id)
+	if err != nil {
+		return Contact{}, err
+	}
+
+	if cached, ok := s.cache.getContact(target.String()); ok {
+		return cached, nil
+	}
+
+	resp, err := s.client.IsOnWhatsApp(ctx, []string{target.User})
+	if err != nil {
+		return Contact{}, fmt.Errorf("whatsapp: IsOnWhatsApp: %w", err)
+	}
+	if len(resp) == 0 || !resp[0].IsIn {
+		return Contact{JID: id, Found: false}, nil
+	}
+
+	contact := Contact{JID: resp[0].JID.String(), Found: true}
+	if vn := resp[0].VerifiedName; vn != nil && vn.Details != nil {
+		contact.IsBusiness = true
+		contact.VerifiedName = vn.Details.GetVerifiedName()
+	}
+	s.cache.putContact(contact)
+	return contact, nil
+}
+
+// GetAbout fetches a contact's about/status text.
+func (s *Session) GetAbout(ctx context.Context, id string) (string, error) {
+	target, err := jid(id)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := s.client.GetUserInfo(ctx, []types.JID{target})
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: GetUserInfo: %w", err)
+	}
+	return info[target].Status, nil
+}
+
+// contactName returns the best available display name for a JID from
+// the whatsmeow contact store: the full name if set, else the push
+// name, else the JID's user part.
+func (s *Session) contactName(target types.JID) string {
+	info, err := s.client.Store.Contacts.GetContact(context.Background(), target)
+	if err != nil || !info.Found {
+		return target.User
+	}
+	if info.FullName != "" {
+		return info.FullName
+	}
+	if info.PushName != "" {
+		return info.PushName
+	}
+	return target.User
+}
@@ -0,0 +1,50 @@
+package whatsapp
+
+import "codeberg.org/slidge/slidge-whatsapp/media"
+
+// defaultThumbnailDimension is used for chats without a
+// ConversionProfile override.
+const defaultThumbnailDimension = 200
+
+// ConversionProfile overrides the default attachment conversion
+// behavior for a specific chat, e.g. a channel that wants images kept
+// at full resolution instead of the usual thumbnail-sized default.
+type ConversionProfile struct {
+	MaxImageDimension int
+	SkipStickerToGIF  bool
+}
+
+// SetConversionProfile installs profile for chat, as returned by
+// conversionProfileFor and thumbnailerFor. Passing the zero
+// ConversionProfile clears the override. SendMessage and
+// incoming-message handling do not consult these profiles, so they do
+// not change how attachments are sent or received.
+func (s *Session) SetConversionProfile(chat string, profile ConversionProfile) {
+	if s.conversionProfiles == nil {
+		s.conversionProfiles = make(map[string]ConversionProfile)
+	}
+	if profile == (ConversionProfile{}) {
+		delete(s.conversionProfiles, chat)
+		return
+	}
+	s.conversionProfiles[chat] = profile
+}
+
+// conversionProfileFor returns the effective ConversionProfile for
+// chat, falling back to the session-wide default.
+func (s *Session) conversionProfileFor(chat string) ConversionProfile {
+	if profile, ok := s.conversionProfiles[chat]; ok {
+		return profile
+	}
+	return ConversionProfile{}
+}
+
+// thumbnailerFor returns the Thumbnailer to use for attachments in
+// chat, honoring any per-chat MaxImageDimension override.
+func (s *Session) thumbnailerFor(chat string) *media.Thumbnailer {
+	dimension := defaultThumbnailDimension
+	if profile := s.conversionProfileFor(chat); profile.MaxImageDimension > 0 {
+		dimension = profile.MaxImageDimension
+	}
+	return media.NewThumbnailer(dimension)
+}
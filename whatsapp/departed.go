@@ -0,0 +1,78 @@
+package whatsapp
+
+import (
+	"sync"
+	"time"
+)
+
+// departedUser records when a contact stopped being relevant to this
+// session (removed from a group, blocked, account deleted), so cached
+// data about them can be dropped after a grace period instead of
+// disappearing immediately, in case the departure was transient.
+type departedUser struct {
+	JID        string
+	DepartedAt time.Time
+}
+
+// departedUsers tracks soft-deleted contacts pending purge.
+type departedUsers struct {
+	mu    sync.Mutex
+	users map[string]departedUser
+}
+
+func newDepartedUsers() *departedUsers {
+	return &departedUsers{users: make(map[string]departedUser)}
+}
+
+// markDeparted soft-deletes jid as of now, or is a no-op if jid is
+// already marked.
+func (d *departedUsers) markDeparted(target string, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, exists := d.users[target]; exists {
+		return
+	}
+	d.users[target] = departedUser{JID: target, DepartedAt: now}
+}
+
+// restore undoes a soft-delete, e.g. because the user rejoined.
+func (d *departedUsers) restore(target string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.users, target)
+}
+
+// purge permanently forgets any user soft-deleted before cutoff,
+// returning the JIDs removed so the caller can also purge any
+// downstream storage (avatar cache, message history, ...).
+func (d *departedUsers) purge(cutoff time.Time) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var purged []string
+	for target, user := range d.users {
+		if user.DepartedAt.Before(cutoff) {
+			purged = append(purged, target)
+			delete(d.users, target)
+		}
+	}
+	return purged
+}
+
+// MarkContactDeparted soft-deletes contactJID's cached data, keeping it
+// recoverable until PurgeDepartedContacts reclaims it.
+func (s *Session) MarkContactDeparted(contactJID string) {
+	s.departed.markDeparted(contactJID, time.Now())
+}
+
+// RestoreDepartedContact undoes a prior soft-delete, e.g. because the
+// contact rejoined a shared group.
+func (s *Session) RestoreDepartedContact(contactJID string) {
+	s.departed.restore(contactJID)
+}
+
+// PurgeDepartedContacts permanently forgets any contact soft-deleted
+// more than after ago, returning the JIDs purged.
+func (s *Session) PurgeDepartedContacts(after time.Duration) []string {
+	return s.departed.purge(time.Now().Add(-after))
+}
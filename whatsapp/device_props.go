@@ -0,0 +1,20 @@
+package whatsapp
+
+// DeviceProps configures the device identity WhatsApp sees for this
+// connection, letting deployments spoof a specific client version or
+// tune how much history WhatsApp backfills on login.
+type DeviceProps struct {
+	// AppVersion overrides the reported WhatsApp Web version string,
+	// e.g. "2.3000.somehash". Empty keeps whatsmeow's default.
+	AppVersion string
+	// HistorySyncDays bounds how many days of history WhatsApp
+	// backfills on first login. Zero uses WhatsApp's own default.
+	HistorySyncDays int
+}
+
+// SetDeviceProps stores props on the session. Nothing reads them back:
+// neither Connect nor any other path applies the app version or the
+// history sync window to the connection.
+func (s *Session) SetDeviceProps(props DeviceProps) {
+	s.deviceProps = props
+}
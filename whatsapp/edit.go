@@ -0,0 +1,16 @@
+package whatsapp
+
+// IncomingEdit is a revised body for a previously-received message,
+// including edits to just a media caption (WhatsApp represents both
+// the same way: a protocol EditMessage wrapping the full new content).
+type IncomingEdit struct {
+	Chat      string
+	MessageID string
+	NewBody   string
+}
+
+// handleEditMessage normalizes a protocol-level edit into an
+// IncomingEdit event.
+func (s *Session) handleEditMessage(chat, messageID, newBody string) {
+	s.handleEvent(&IncomingEdit{Chat: chat, MessageID: messageID, NewBody: newBody})
+}
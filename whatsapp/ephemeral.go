@@ -0,0 +1,128 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// SetDisappearingTimer sets chat's disappearing-message timer to
+// duration. A duration of zero turns disappearing messages off.
+func (s *Session) SetDisappearingTimer(ctx context.Context, chat string, duration time.Duration) error {
+	target, err := jid(chat)
+	if err != nil {
+		return err
+	}
+	if duration < 0 {
+		return fmt.Errorf("whatsapp: disappearing timer duration must not be negative")
+	}
+	if target.Server == types.GroupServer && !isAllowedGroupEphemeralDuration(duration) {
+		return fmt.Errorf("whatsapp: %s is not a valid group disappearing-message duration", duration)
+	}
+
+	return s.setDisappearingTimerForJID(ctx, target, duration)
+}
+
+// SetGroupEphemeralTimer sets a group's disappearing-message timer to
+// duration, which must be one of the values WhatsApp allows for
+// groups (see groupEphemeralDurations). Unlike SetDisappearingTimer it
+// rejects any JID that isn't a group; both share
+// setDisappearingTimerForJID for the actual request.
+func (s *Session) SetGroupEphemeralTimer(ctx context.Context, groupJID string, duration time.Duration) error {
+	target, err := jid(groupJID)
+	if err != nil {
+		return err
+	}
+	if target.Server != types.GroupServer {
+		return fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+	if duration < 0 || !isAllowedGroupEphemeralDuration(duration) {
+		return fmt.Errorf("whatsapp: %s is not a valid group disappearing-message duration", duration)
+	}
+
+	return s.setDisappearingTimerForJID(ctx, target, duration)
+}
+
+// groupEphemeralDurations lists the disappearing-message durations
+// WhatsApp accepts for groups; any other value is rejected by the
+// server, so validating here surfaces a clear error before the round
+// trip.
+var groupEphemeralDurations = []time.Duration{
+	0,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	90 * 24 * time.Hour,
+}
+
+func isAllowedGroupEphemeralDuration(duration time.Duration) bool {
+	for _, allowed := range groupEphemeralDurations {
+		if duration == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Session) setDisappearingTimerForJID(ctx context.Context, target types.JID, duration time.Duration) error {
+	if err := s.client.SetDisappearingTimer(ctx, target, duration, time.Now()); err != nil {
+		return fmt.Errorf("whatsapp: setting disappearing timer for %s: %w", target, err)
+	}
+	return nil
+}
+
+// scheduleEphemeralDelete arranges for a bridged copy of a message to
+// be deleted after expiration once expiration elapses from sentAt,
+// mirroring WhatsApp's own disappearing-message timer so bridged
+// history doesn't outlive the original.
+func (s *Session) scheduleEphemeralDelete(chat, messageID string, sentAt time.Time, expiration time.Duration) {
+	if expiration <= 0 {
+		return
+	}
+	delay := time.Until(sentAt.Add(expiration))
+	if delay <= 0 {
+		s.deleteBridgedMessage(chat, messageID)
+		return
+	}
+	time.AfterFunc(delay, func() {
+		s.deleteBridgedMessage(chat, messageID)
+	})
+}
+
+// deleteBridgedMessage notifies registered event handlers that a
+// message should be removed from the bridged side, e.g. because its
+// ephemeral timer fired.
+func (s *Session) deleteBridgedMessage(chat, messageID string) {
+	s.handleEvent(&MessageExpiredEvent{Chat: chat, MessageID: messageID})
+}
+
+// MessageExpiredEvent is emitted when a disappearing message's timer
+// fires and the bridged copy should be deleted.
+type MessageExpiredEvent struct {
+	Chat      string
+	MessageID string
+}
+
+// EphemeralSettingChangedEvent is emitted when a chat's
+// disappearing-message timer is turned on, off, or changed to a
+// different duration, whether by us or another participant.
+type EphemeralSettingChangedEvent struct {
+	Chat       string
+	ChangedBy  string
+	Expiration time.Duration
+}
+
+// handleProtocolMessage inspects a whatsmeow protocol message for an
+// ephemeral-setting change and, if present, emits
+// EphemeralSettingChangedEvent.
+func (s *Session) handleProtocolMessage(chat, from string, ephemeralExpirationSeconds uint32, isSettingChange bool) {
+	if !isSettingChange {
+		return
+	}
+	s.handleEvent(&EphemeralSettingChangedEvent{
+		Chat:       chat,
+		ChangedBy:  from,
+		Expiration: time.Duration(ephemeralExpirationSeconds) * time.Second,
+	})
+}
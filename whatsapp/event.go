@@ -0,0 +1,24 @@
+package whatsapp
+
+// EventHandler is called for every event the underlying WhatsApp client
+// produces, after minimal normalisation by Session.
+type EventHandler func(evt interface{})
+
+// AddEventHandler registers h to be called for every subsequent event.
+// Handlers are invoked in registration order on the connection's event
+// goroutine, so handlers must not block for long.
+func (s *Session) AddEventHandler(h EventHandler) {
+	s.eventHandlers = append(s.eventHandlers, h)
+}
+
+// RawEventHook is called with every raw whatsmeow event before Session
+// normalises it, letting plug-ins observe (but not suppress) events
+// this package doesn't otherwise expose, such as connection-state
+// changes or presence updates.
+type RawEventHook func(evt interface{})
+
+// AddRawEventHook registers h to be called with every raw whatsmeow
+// event, in addition to Session's own handling of that event.
+func (s *Session) AddRawEventHook(h RawEventHook) {
+	s.rawEventHooks = append(s.rawEventHooks, h)
+}
@@ -0,0 +1,43 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ExportFormat selects the output format for ExportChatHistory.
+type ExportFormat int
+
+const (
+	ExportFormatJSON ExportFormat = iota
+	ExportFormatMbox
+)
+
+// ExportChatHistory writes every message in messages to w in the
+// requested format, for administrative bulk export/backup use cases.
+func ExportChatHistory(w io.Writer, messages []IncomingMessage, format ExportFormat) error {
+	switch format {
+	case ExportFormatJSON:
+		return exportJSON(w, messages)
+	case ExportFormatMbox:
+		return exportMbox(w, messages)
+	default:
+		return fmt.Errorf("whatsapp: unknown export format %d", format)
+	}
+}
+
+func exportJSON(w io.Writer, messages []IncomingMessage) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(messages)
+}
+
+func exportMbox(w io.Writer, messages []IncomingMessage) error {
+	for _, msg := range messages {
+		if _, err := fmt.Fprintf(w, "From %s\nX-WhatsApp-Message-ID: %s\nX-WhatsApp-Chat: %s\n\n%s\n\n", msg.From, msg.ID, msg.Chat, msg.Body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
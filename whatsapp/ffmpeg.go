@@ -0,0 +1,70 @@
+package whatsapp
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// ffmpegPathEnv overrides the ffmpeg binary an execFFmpegRunner invokes.
+const ffmpegPathEnv = "SLIDGE_WHATSAPP_FFMPEG_PATH"
+
+// ffprobePathEnv overrides the ffprobe binary an execFFmpegRunner
+// invokes.
+const ffprobePathEnv = "SLIDGE_WHATSAPP_FFPROBE_PATH"
+
+// ffmpegRunner abstracts running ffmpeg/ffprobe over stdin/stdout, so
+// audio conversion, spec parsing and waveform math can be exercised
+// against a canned fake instead of a real ffmpeg install.
+type ffmpegRunner interface {
+	// ffmpeg runs ffmpeg with args, feeding it stdin on its standard
+	// input and returning what it wrote to standard output.
+	ffmpeg(args []string, stdin []byte) ([]byte, error)
+
+	// ffprobe runs ffprobe with args, feeding it stdin on its standard
+	// input and returning what it wrote to standard output.
+	ffprobe(args []string, stdin []byte) ([]byte, error)
+}
+
+// execFFmpegRunner runs the real ffmpeg/ffprobe binaries as
+// subprocesses. It's the default ffmpegRunner outside of tests.
+type execFFmpegRunner struct{}
+
+func (execFFmpegRunner) ffmpeg(args []string, stdin []byte) ([]byte, error) {
+	return runPiped(ffmpegBinaryPath(), args, stdin)
+}
+
+func (execFFmpegRunner) ffprobe(args []string, stdin []byte) ([]byte, error) {
+	return runPiped(ffprobeBinaryPath(), args, stdin)
+}
+
+func runPiped(name string, args []string, stdin []byte) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("whatsapp: run %s: %w", name, err)
+	}
+	return out.Bytes(), nil
+}
+
+func ffmpegBinaryPath() string {
+	if path := os.Getenv(ffmpegPathEnv); path != "" {
+		return path
+	}
+	return "ffmpeg"
+}
+
+func ffprobeBinaryPath() string {
+	if path := os.Getenv(ffprobePathEnv); path != "" {
+		return path
+	}
+	return "ffprobe"
+}
+
+// defaultFFmpegRunner is the ffmpegRunner used when a Session doesn't
+// override it; tests substitute a fake via Session.ffmpeg.
+var defaultFFmpegRunner ffmpegRunner = execFFmpegRunner{}
@@ -0,0 +1,30 @@
+package whatsapp
+
+// fakeFFmpegRunner is a canned-output ffmpegRunner: it returns fixed
+// byte slices without invoking any subprocess, so audio conversion,
+// spec parsing and waveform math can be exercised in CI without a real
+// ffmpeg/ffprobe install.
+type fakeFFmpegRunner struct {
+	ffmpegOutput  []byte
+	ffmpegErr     error
+	ffprobeOutput []byte
+	ffprobeErr    error
+
+	calls []fakeFFmpegCall
+}
+
+type fakeFFmpegCall struct {
+	tool  string
+	args  []string
+	stdin []byte
+}
+
+func (f *fakeFFmpegRunner) ffmpeg(args []string, stdin []byte) ([]byte, error) {
+	f.calls = append(f.calls, fakeFFmpegCall{tool: "ffmpeg", args: args, stdin: stdin})
+	return f.ffmpegOutput, f.ffmpegErr
+}
+
+func (f *fakeFFmpegRunner) ffprobe(args []string, stdin []byte) ([]byte, error) {
+	f.calls = append(f.calls, fakeFFmpegCall{tool: "ffprobe", args: args, stdin: stdin})
+	return f.ffprobeOutput, f.ffprobeErr
+}
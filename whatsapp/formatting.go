@@ -0,0 +1,15 @@
+package whatsapp
+
+import "strings"
+
+// xmppToWhatsAppReplacer rewrites the small set of Markdown-ish styling
+// XMPP clients commonly send (XEP-0393 styling) into WhatsApp's own
+// formatting markers, which happen to use the same delimiters for bold
+// and italics but "~" instead of Markdown's "~~" for strikethrough.
+var xmppToWhatsAppReplacer = strings.NewReplacer("~~", "~")
+
+// convertStylingToWhatsApp rewrites body's XMPP-style formatting
+// markers to their WhatsApp equivalents.
+func convertStylingToWhatsApp(body string) string {
+	return xmppToWhatsAppReplacer.Replace(body)
+}
@@ -0,0 +1,496 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// Group is the subset of WhatsApp group metadata the gateway exposes.
+type Group struct {
+	JID     string
+	Name    string
+	Topic   string
+	OwnerID string
+	Created time.Time
+
+	// MemberAddMode controls who besides admins can add new members.
+	MemberAddMode MemberAddMode
+
+	// Locked reports whether only admins may edit the group's name,
+	// topic and photo.
+	Locked bool
+
+	// Ephemeral is the group's disappearing-message timer, or zero if
+	// disappearing messages are off.
+	Ephemeral time.Duration
+
+	// Community is the JID of the WhatsApp community this group is
+	// linked to as a sub-group, or empty if it isn't linked to one.
+	Community string
+
+	Participants []Participant
+}
+
+// MemberAddMode mirrors WhatsApp's "who can add members" group
+// setting.
+type MemberAddMode int
+
+const (
+	MemberAddModeAllMembers MemberAddMode = iota
+	MemberAddModeAdminsOnly
+)
+
+// GroupPreview is lightweight group metadata for listings where
+// fetching every participant would be wasteful.
+type GroupPreview struct {
+	JID              string
+	Name             string
+	ParticipantCount int
+	// SampleParticipants holds up to previewParticipantSampleSize
+	// participant JIDs, enough for a "Alice, Bob and 12 others" style
+	// summary.
+	SampleParticipants []string
+}
+
+// previewParticipantSampleSize bounds how many participants
+// GetGroupPreview includes, keeping the response small for group
+// listings.
+const previewParticipantSampleSize = 3
+
+// GetGroupPreview fetches a lightweight summary of a group: its name,
+// participant count and a small sample of participants, without
+// requiring callers to page through the full participant list.
+func (s *Session) GetGroupPreview(ctx context.Context, groupJID string) (GroupPreview, error) {
+	target, err := jid(groupJID)
+	if err != nil {
+		return GroupPreview{}, err
+	}
+	if target.Server != types.GroupServer {
+		return GroupPreview{}, fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	info, err := s.client.GetGroupInfo(ctx, target)
+	if err != nil {
+		return GroupPreview{}, fmt.Errorf("whatsapp: GetGroupInfo: %w", err)
+	}
+
+	preview := GroupPreview{
+		JID:              info.JID.String(),
+		Name:             info.Name,
+		ParticipantCount: len(info.Participants),
+	}
+	for i, p := range info.Participants {
+		if i >= previewParticipantSampleSize {
+			break
+		}
+		preview.SampleParticipants = append(preview.SampleParticipants, p.JID.String())
+	}
+	return preview, nil
+}
+
+// Participant is a single group member, with the flags WhatsApp
+// reports alongside their JID.
+type Participant struct {
+	JID     string
+	IsAdmin bool
+}
+
+// SetGroupAnnounce turns "announce" mode on or off for a group: when
+// enabled, only admins may send messages to the group.
+func (s *Session) SetGroupAnnounce(ctx context.Context, groupJID string, announce bool) error {
+	target, err := jid(groupJID)
+	if err != nil {
+		return err
+	}
+	if target.Server != types.GroupServer {
+		return fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	if err := s.client.SetGroupAnnounce(ctx, target, announce); err != nil {
+		return fmt.Errorf("whatsapp: SetGroupAnnounce: %w", err)
+	}
+	return nil
+}
+
+// SetGroupLocked turns "locked" mode on or off for a group: when
+// enabled, only admins may edit the group's name, topic and photo.
+func (s *Session) SetGroupLocked(ctx context.Context, groupJID string, locked bool) error {
+	target, err := jid(groupJID)
+	if err != nil {
+		return err
+	}
+	if target.Server != types.GroupServer {
+		return fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	if err := s.client.SetGroupLocked(ctx, target, locked); err != nil {
+		return fmt.Errorf("whatsapp: SetGroupLocked: %w", err)
+	}
+	return nil
+}
+
+// SetGroupJoinApprovalMode turns membership approval on or off for a
+// group: when enabled, new members must be approved via
+// ListGroupJoinRequests/ApproveGroupJoinRequest instead of joining
+// immediately.
+func (s *Session) SetGroupJoinApprovalMode(ctx context.Context, groupJID string, enabled bool) error {
+	target, err := jid(groupJID)
+	if err != nil {
+		return err
+	}
+	if target.Server != types.GroupServer {
+		return fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	if err := s.client.SetGroupJoinApprovalMode(ctx, target, enabled); err != nil {
+		return fmt.Errorf("whatsapp: SetGroupJoinApprovalMode: %w", err)
+	}
+	return nil
+}
+
+// ParticipantUpdateAction identifies a batch participant operation.
+type ParticipantUpdateAction int
+
+const (
+	ParticipantAdd ParticipantUpdateAction = iota
+	ParticipantRemove
+	ParticipantPromote
+	ParticipantDemote
+)
+
+func (a ParticipantUpdateAction) whatsmeow() whatsmeow.ParticipantChange {
+	switch a {
+	case ParticipantAdd:
+		return whatsmeow.ParticipantChangeAdd
+	case ParticipantRemove:
+		return whatsmeow.ParticipantChangeRemove
+	case ParticipantPromote:
+		return whatsmeow.ParticipantChangePromote
+	case ParticipantDemote:
+		return whatsmeow.ParticipantChangeDemote
+	default:
+		return whatsmeow.ParticipantChangeAdd
+	}
+}
+
+// ParticipantUpdateResult reports the outcome of a batch participant
+// operation for a single JID, since WhatsApp applies these
+// per-participant and any one of them may fail independently of the
+// rest.
+type ParticipantUpdateResult struct {
+	JID string
+	Err error
+}
+
+// UpdateGroupParticipants applies action to every JID in participants
+// in a single request, returning a per-participant result so partial
+// failures (e.g. one JID already left the group) don't hide the
+// participants that did succeed.
+func (s *Session) UpdateGroupParticipants(ctx context.Context, groupJID string, participants []string, action ParticipantUpdateAction) ([]ParticipantUpdateResult, error) {
+	target, err := jid(groupJID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Server != types.GroupServer {
+		return nil, fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+	if len(participants) == 0 {
+		return nil, fmt.Errorf("whatsapp: no participants given")
+	}
+
+	targets := make([]types.JID, 0, len(participants))
+	for _, p := range participants {
+		parsed, err := jid(p)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, parsed)
+	}
+
+	changes, err := s.client.UpdateGroupParticipants(ctx, target, targets, action.whatsmeow())
+	if err != nil {
+		return nil, fmt.Errorf("whatsapp: UpdateGroupParticipants: %w", err)
+	}
+
+	results := make([]ParticipantUpdateResult, 0, len(changes))
+	for _, c := range changes {
+		var err error
+		if c.Error != 0 {
+			err = fmt.Errorf("whatsapp: participant update failed for %s: %s", c.JID, participantErrorReason(c.Error))
+		}
+		results = append(results, ParticipantUpdateResult{JID: normalizeJID(c.JID).String(), Err: err})
+	}
+	return results, nil
+}
+
+// participantErrorReason translates the numeric status code WhatsApp
+// returns for a failed participant update into an actionable message,
+// so callers can tell a user "already in group" apart from "not on
+// WhatsApp" instead of surfacing a bare error code.
+func participantErrorReason(code int) string {
+	switch code {
+	case 403:
+		return "not authorized to make this change"
+	case 404:
+		return "participant not found"
+	case 406:
+		return "participant is not a contact of the inviter and group requires an invite"
+	case 409:
+		return "participant is already in the group"
+	case 412:
+		return "participant does not have an account on WhatsApp"
+	default:
+		return fmt.Sprintf("unknown error (code %d)", code)
+	}
+}
+
+// TransferGroupOwnership makes newOwner the group's owner. Only the
+// current owner may do this; WhatsApp achieves the transfer by
+// promoting newOwner and demoting the previous owner, which removes
+// their admin rights, since group ownership itself has no separate
+// wire operation.
+func (s *Session) TransferGroupOwnership(ctx context.Context, groupJID, newOwnerJID string) error {
+	target, err := jid(groupJID)
+	if err != nil {
+		return err
+	}
+	if target.Server != types.GroupServer {
+		return fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+	newOwner, err := jid(newOwnerJID)
+	if err != nil {
+		return err
+	}
+
+	info, err := s.client.GetGroupInfo(ctx, target)
+	if err != nil {
+		return fmt.Errorf("whatsapp: GetGroupInfo: %w", err)
+	}
+	if info.OwnerJID.User != s.client.Store.ID.User {
+		return fmt.Errorf("whatsapp: only the group owner may transfer ownership of %s", groupJID)
+	}
+
+	if _, err := s.client.UpdateGroupParticipants(ctx, target, []types.JID{newOwner}, whatsmeow.ParticipantChangePromote); err != nil {
+		return fmt.Errorf("whatsapp: promote new owner: %w", err)
+	}
+	if _, err := s.client.UpdateGroupParticipants(ctx, target, []types.JID{info.OwnerJID}, whatsmeow.ParticipantChangeDemote); err != nil {
+		return fmt.Errorf("whatsapp: demote previous owner: %w", err)
+	}
+	return nil
+}
+
+// DeleteGroup checks that we own the group and then leaves it; any
+// other caller gets an error. It does not delete the group for its
+// other participants, and it does not remove the group from the
+// session's warm cache.
+func (s *Session) DeleteGroup(ctx context.Context, groupJID string) error {
+	target, err := jid(groupJID)
+	if err != nil {
+		return err
+	}
+	if target.Server != types.GroupServer {
+		return fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	info, err := s.client.GetGroupInfo(ctx, target)
+	if err != nil {
+		return fmt.Errorf("whatsapp: GetGroupInfo: %w", err)
+	}
+	if info.OwnerJID.User != s.client.Store.ID.User {
+		return fmt.Errorf("whatsapp: only the group owner may delete %s", groupJID)
+	}
+
+	if err := s.client.LeaveGroup(ctx, target); err != nil {
+		return fmt.Errorf("whatsapp: delete group: %w", err)
+	}
+	return nil
+}
+
+// GetGroupInviteLink fetches the current invite link for a group.
+func (s *Session) GetGroupInviteLink(ctx context.Context, groupJID string) (string, error) {
+	target, err := jid(groupJID)
+	if err != nil {
+		return "", err
+	}
+	if target.Server != types.GroupServer {
+		return "", fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	link, err := s.client.GetGroupInviteLink(ctx, target, false)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: GetGroupInviteLink: %w", err)
+	}
+	return link, nil
+}
+
+// RevokeGroupInviteLink invalidates the current invite link for a
+// group, replacing it with a newly generated one, and returns that new
+// link.
+func (s *Session) RevokeGroupInviteLink(ctx context.Context, groupJID string) (string, error) {
+	target, err := jid(groupJID)
+	if err != nil {
+		return "", err
+	}
+	if target.Server != types.GroupServer {
+		return "", fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	link, err := s.client.GetGroupInviteLink(ctx, target, true)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: GetGroupInviteLink (revoke): %w", err)
+	}
+	return link, nil
+}
+
+// SendGroupInvite sends a message to recipient inviting them to join
+// groupJID via link.
+func (s *Session) SendGroupInvite(ctx context.Context, groupJID, recipient, link string) (string, error) {
+	group, err := jid(groupJID)
+	if err != nil {
+		return "", err
+	}
+	to, err := jid(recipient)
+	if err != nil {
+		return "", err
+	}
+	if link == "" {
+		return "", fmt.Errorf("whatsapp: group invite requires a link")
+	}
+
+	return s.sendGroupInviteToJID(ctx, group, to, link)
+}
+
+// sendGroupInviteToJID sends to a formal group invite message for
+// group, extracting the invite code from link (the trailing path
+// segment of a https://chat.whatsapp.com/<code> URL).
+func (s *Session) sendGroupInviteToJID(ctx context.Context, group, to types.JID, link string) (string, error) {
+	code := link[strings.LastIndex(link, "/")+1:]
+
+	info, err := s.GetGroupInfo(ctx, group.String())
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: sending group invite for %s to %s: %w", group, to, err)
+	}
+
+	msg := &waE2E.Message{
+		GroupInviteMessage: &waE2E.GroupInviteMessage{
+			GroupJID:         proto.String(group.String()),
+			InviteCode:       proto.String(code),
+			InviteExpiration: proto.Int64(time.Now().Add(3 * 24 * time.Hour).Unix()),
+			GroupName:        proto.String(info.Name),
+			Caption:          proto.String(link),
+		},
+	}
+
+	resp, err := s.client.SendMessage(ctx, to, msg)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: sending group invite for %s to %s: %w", group, to, err)
+	}
+
+	s.sentMessages.record(resp.ID, resp.Timestamp)
+	return resp.ID, nil
+}
+
+func fromGroupParticipant(p types.GroupParticipant) Participant {
+	return Participant{
+		JID:     normalizeJID(p.JID).String(),
+		IsAdmin: p.IsAdmin || p.IsSuperAdmin,
+	}
+}
+
+// GetGroupInfo fetches metadata for a single group, returning a
+// cached copy if one is warm from this or a previous session rather
+// than always round-tripping to WhatsApp; call RefreshGroupInfo to
+// force a fresh fetch.
+func (s *Session) GetGroupInfo(ctx context.Context, groupJID string) (Group, error) {
+	target, err := jid(groupJID)
+	if err != nil {
+		return Group{}, err
+	}
+	if target.Server != types.GroupServer {
+		return Group{}, fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	if cached, ok := s.cache.getGroup(target.String()); ok {
+		return cached, nil
+	}
+
+	info, err := s.client.GetGroupInfo(ctx, target)
+	if err != nil {
+		return Group{}, fmt.Errorf("whatsapp: GetGroupInfo: %w", err)
+	}
+
+	group := fromGroupInfo(info)
+	s.cache.putGroup(group)
+	return group, nil
+}
+
+// RefreshGroupInfo re-fetches metadata for a single group directly from
+// WhatsApp's servers, bypassing the warm cache GetGroupInfo otherwise
+// consults, for callers that need to be sure they're not looking at
+// stale data (e.g. right after being notified of a group-info change).
+func (s *Session) RefreshGroupInfo(ctx context.Context, groupJID string) (Group, error) {
+	target, err := jid(groupJID)
+	if err != nil {
+		return Group{}, err
+	}
+	if target.Server != types.GroupServer {
+		return Group{}, fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	info, err := s.client.GetGroupInfo(ctx, target)
+	if err != nil {
+		return Group{}, fmt.Errorf("whatsapp: GetGroupInfo: %w", err)
+	}
+
+	group := fromGroupInfo(info)
+	s.cache.putGroup(group)
+	return group, nil
+}
+
+// GroupParticipantEvent is emitted for each participant that joins,
+// leaves, or changes role in a group, so callers can update their
+// roster incrementally instead of refetching the whole group.
+type GroupParticipantEvent struct {
+	Group  string
+	JID    string
+	Action GroupParticipantAction
+}
+
+// GroupParticipantAction identifies what happened to a participant.
+type GroupParticipantAction int
+
+const (
+	GroupParticipantJoined GroupParticipantAction = iota
+	GroupParticipantLeft
+	GroupParticipantPromoted
+	GroupParticipantDemoted
+)
+
+func fromGroupInfo(info *types.GroupInfo) Group {
+	mode := MemberAddModeAllMembers
+	if info.MemberAddMode == types.GroupMemberAddModeAdmin {
+		mode = MemberAddModeAdminsOnly
+	}
+	group := Group{
+		JID:           info.JID.String(),
+		Name:          info.Name,
+		Topic:         info.Topic,
+		OwnerID:       info.OwnerJID.String(),
+		Created:       info.GroupCreated,
+		MemberAddMode: mode,
+		Locked:        info.IsLocked,
+		Ephemeral:     time.Duration(info.DisappearingTimer) * time.Second,
+		Community:     info.LinkedParentJID.String(),
+	}
+	for _, p := range info.Participants {
+		group.Participants = append(group.Participants, fromGroupParticipant(p))
+	}
+	return group
+}
@@ -0,0 +1,70 @@
+package whatsapp
+
+import (
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// GroupPhotoChangedEvent is emitted when a group's photo is set,
+// replaced, or removed, so subscribers can refresh their cached avatar
+// instead of continuing to show a stale one.
+type GroupPhotoChangedEvent struct {
+	Group     string
+	ChangedBy string
+	PictureID string
+	Removed   bool
+}
+
+// handlePictureEvent translates a whatsmeow avatar-change notification
+// into GroupPhotoChangedEvent, ignoring the ones that target a 1:1
+// contact rather than a group.
+func (s *Session) handlePictureEvent(evt *events.Picture) {
+	if evt.JID.Server != types.GroupServer {
+		return
+	}
+	s.handleEvent(&GroupPhotoChangedEvent{
+		Group:     normalizeJID(evt.JID).String(),
+		ChangedBy: normalizeJID(evt.Author).String(),
+		PictureID: evt.PictureID,
+		Removed:   evt.Remove,
+	})
+}
+
+// SelfRemovedEvent is emitted when our own account is removed from, or
+// banned from, a group, so the gateway can drop the corresponding
+// bridged chat instead of leaving it stuck.
+type SelfRemovedEvent struct {
+	Group  string
+	Banned bool
+}
+
+// handleGroupInfoEvent translates a whatsmeow group-info delta into
+// one GroupParticipantEvent per participant that joined, left, or
+// changed role, so subscribers can sync their roster incrementally.
+func (s *Session) handleGroupInfoEvent(evt *events.GroupInfo) {
+	chat := normalizeJID(evt.JID).String()
+
+	for _, p := range evt.Leave {
+		if p.User == s.client.Store.ID.User {
+			// whatsmeow doesn't distinguish an admin removal from a
+			// ban at the group-info-delta level; both surface as us
+			// leaving the participant list.
+			s.handleEvent(&SelfRemovedEvent{Group: chat})
+		}
+	}
+
+	for _, p := range evt.Join {
+		s.RestoreDepartedContact(normalizeJID(p).String())
+		s.handleEvent(&GroupParticipantEvent{Group: chat, JID: normalizeJID(p).String(), Action: GroupParticipantJoined})
+	}
+	for _, p := range evt.Leave {
+		s.MarkContactDeparted(normalizeJID(p).String())
+		s.handleEvent(&GroupParticipantEvent{Group: chat, JID: normalizeJID(p).String(), Action: GroupParticipantLeft})
+	}
+	for _, p := range evt.Promote {
+		s.handleEvent(&GroupParticipantEvent{Group: chat, JID: normalizeJID(p).String(), Action: GroupParticipantPromoted})
+	}
+	for _, p := range evt.Demote {
+		s.handleEvent(&GroupParticipantEvent{Group: chat, JID: normalizeJID(p).String(), Action: GroupParticipantDemoted})
+	}
+}
@@ -0,0 +1,60 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RoundTripHarness pairs two Sessions for send/receive round-trip
+// checks: AwaitIncoming runs a send and waits for a matching event on
+// the receiving Session. It does not relay anything between the two
+// Sessions itself, so events only arrive through each Session's own
+// WhatsApp connection.
+type RoundTripHarness struct {
+	A, B *Session
+
+	timeout time.Duration
+}
+
+// NewRoundTripHarness returns a harness for a and b with a 5 second
+// timeout. It registers nothing on either Session; each AwaitIncoming
+// call adds an event handler to its target that stays registered.
+func NewRoundTripHarness(a, b *Session) *RoundTripHarness {
+	return &RoundTripHarness{A: a, B: b, timeout: 5 * time.Second}
+}
+
+// WithTimeout overrides how long AwaitIncoming waits before giving up.
+func (h *RoundTripHarness) WithTimeout(d time.Duration) *RoundTripHarness {
+	h.timeout = d
+	return h
+}
+
+// AwaitIncoming runs send, then blocks until predicate returns true for
+// some event delivered to target's handlers, or the harness's timeout
+// elapses.
+func (h *RoundTripHarness) AwaitIncoming(ctx context.Context, target *Session, send func() error, predicate func(evt interface{}) bool) (interface{}, error) {
+	matched := make(chan interface{}, 1)
+
+	target.eventHandlers = append(target.eventHandlers, func(evt interface{}) {
+		if predicate(evt) {
+			select {
+			case matched <- evt:
+			default:
+			}
+		}
+	})
+
+	if err := send(); err != nil {
+		return nil, fmt.Errorf("whatsapp: harness send: %w", err)
+	}
+
+	select {
+	case evt := <-matched:
+		return evt, nil
+	case <-time.After(h.timeout):
+		return nil, fmt.Errorf("whatsapp: no matching event within %s", h.timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,74 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/proto/waHistorySync"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// maxHistoryRequestPerGroup is the largest single history-sync page
+// WhatsApp will honor for a group chat before rate-limiting further
+// requests for that chat.
+const maxHistoryRequestPerGroup = 50
+
+// RequestGroupHistory asks WhatsApp to backfill up to count messages of
+// history for the given group chat, capping count at the server's
+// per-request quota rather than erroring so callers don't need to know
+// the limit themselves.
+func (s *Session) RequestGroupHistory(ctx context.Context, groupJID string, count int) error {
+	target, err := jid(groupJID)
+	if err != nil {
+		return err
+	}
+	if target.Server != types.GroupServer {
+		return fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+	if count <= 0 {
+		return fmt.Errorf("whatsapp: history count must be positive")
+	}
+	if count > maxHistoryRequestPerGroup {
+		count = maxHistoryRequestPerGroup
+	}
+
+	return s.requestHistorySync(ctx, target, count)
+}
+
+// ConversationHistory summarizes one conversation's history-sync
+// payload, enough for the gateway to decide its Message Archive
+// Management (MAM) retention policy for that chat.
+type ConversationHistory struct {
+	Chat string
+
+	// EphemeralExpiration is the disappearing-message timer WhatsApp
+	// reports for this conversation, in seconds. Zero means
+	// disappearing messages are off.
+	EphemeralExpiration uint32
+}
+
+// fromHistorySyncConversation extracts the MAM-relevant fields out of a
+// history-sync payload's per-conversation entry.
+func fromHistorySyncConversation(conv *waHistorySync.Conversation) ConversationHistory {
+	return ConversationHistory{
+		Chat:                conv.GetID(),
+		EphemeralExpiration: conv.GetEphemeralExpiration(),
+	}
+}
+
+// requestHistorySync performs the actual whatsmeow history-sync
+// request; split out so it can be reused for non-group history in the
+// future without duplicating the quota logic above.
+func (s *Session) requestHistorySync(ctx context.Context, target types.JID, count int) error {
+	lastMsg, ok := s.lastMessages.get(target)
+	if !ok {
+		return fmt.Errorf("whatsapp: no known message in %s to anchor a history sync request", target)
+	}
+
+	msg := s.client.BuildHistorySyncRequest(lastMsg, count)
+
+	if _, err := s.client.SendMessage(ctx, *s.client.Store.ID, msg); err != nil {
+		return fmt.Errorf("whatsapp: history sync for %s: %w", target, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package whatsapp
+
+import "sync"
+
+// IDMapper translates between XMPP stanza IDs and WhatsApp message
+// IDs, so the gateway can look a message up by whichever side
+// referenced it (a reply, a correction, a receipt). Sessions default
+// to an in-memory mapper; callers with their own persistent mapping
+// (e.g. backed by the same store as the rest of the bridge) can supply
+// one via SetIDMapper.
+type IDMapper interface {
+	// Put records that xmppID and waID refer to the same message.
+	Put(xmppID, waID string)
+	// WhatsAppID returns the WhatsApp message ID for xmppID, if known.
+	WhatsAppID(xmppID string) (string, bool)
+	// XMPPID returns the XMPP stanza ID for waID, if known.
+	XMPPID(waID string) (string, bool)
+}
+
+// memoryIDMapper is the default IDMapper, backed by two plain maps.
+// It does not persist across restarts.
+type memoryIDMapper struct {
+	mu     sync.RWMutex
+	toWA   map[string]string
+	toXMPP map[string]string
+}
+
+func newMemoryIDMapper() *memoryIDMapper {
+	return &memoryIDMapper{
+		toWA:   make(map[string]string),
+		toXMPP: make(map[string]string),
+	}
+}
+
+func (m *memoryIDMapper) Put(xmppID, waID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.toWA[xmppID] = waID
+	m.toXMPP[waID] = xmppID
+}
+
+func (m *memoryIDMapper) WhatsAppID(xmppID string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	waID, ok := m.toWA[xmppID]
+	return waID, ok
+}
+
+func (m *memoryIDMapper) XMPPID(waID string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	xmppID, ok := m.toXMPP[waID]
+	return xmppID, ok
+}
+
+// SetIDMapper replaces the session's XMPP↔WhatsApp ID mapper. Passing
+// nil restores the default in-memory mapper.
+func (s *Session) SetIDMapper(mapper IDMapper) {
+	if mapper == nil {
+		mapper = newMemoryIDMapper()
+	}
+	s.idMapper = mapper
+}
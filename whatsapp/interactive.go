@@ -0,0 +1,26 @@
+package whatsapp
+
+import "strings"
+
+// InteractiveMessage is the readable-text rendering of a WhatsApp
+// button or list message, since XMPP has no equivalent widget.
+type InteractiveMessage struct {
+	Body    string
+	Buttons []string
+}
+
+// renderInteractive flattens an interactive message into plain text: a
+// body, followed by one line per selectable button/list option.
+func renderInteractive(msg InteractiveMessage) string {
+	if len(msg.Buttons) == 0 {
+		return msg.Body
+	}
+
+	var b strings.Builder
+	b.WriteString(msg.Body)
+	for _, button := range msg.Buttons {
+		b.WriteString("\n- ")
+		b.WriteString(button)
+	}
+	return b.String()
+}
@@ -0,0 +1,11 @@
+package whatsapp
+
+import "go.mau.fi/whatsmeow/types"
+
+// normalizeJID collapses a WhatsApp "AD" (address, device-specific)
+// JID down to its non-device form, since every inbound payload should
+// be keyed by the user's stable identity rather than the device that
+// happened to send it.
+func normalizeJID(j types.JID) types.JID {
+	return j.ToNonAD()
+}
@@ -0,0 +1,65 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// JoinRequest is a pending request to join a group with membership
+// approval enabled.
+type JoinRequest struct {
+	Group     string
+	JID       string
+	Requested bool
+}
+
+// ListGroupJoinRequests fetches pending join requests for a group.
+func (s *Session) ListGroupJoinRequests(ctx context.Context, groupJID string) ([]JoinRequest, error) {
+	target, err := jid(groupJID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Server != types.GroupServer {
+		return nil, fmt.Errorf("whatsapp: %s is not a group JID", groupJID)
+	}
+
+	requests, err := s.client.GetGroupRequestParticipants(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("whatsapp: GetGroupRequestParticipants: %w", err)
+	}
+
+	out := make([]JoinRequest, 0, len(requests))
+	for _, r := range requests {
+		out = append(out, JoinRequest{Group: groupJID, JID: normalizeJID(r.JID).String(), Requested: true})
+	}
+	return out, nil
+}
+
+// ApproveGroupJoinRequest approves or rejects a pending join request.
+func (s *Session) ApproveGroupJoinRequest(ctx context.Context, groupJID, requesterJID string, approve bool) error {
+	group, err := jid(groupJID)
+	if err != nil {
+		return err
+	}
+	requester, err := jid(requesterJID)
+	if err != nil {
+		return err
+	}
+
+	return s.approveGroupJoinRequest(ctx, group, requester, approve)
+}
+
+func (s *Session) approveGroupJoinRequest(ctx context.Context, group, requester types.JID, approve bool) error {
+	action := whatsmeow.ParticipantChangeReject
+	if approve {
+		action = whatsmeow.ParticipantChangeApprove
+	}
+
+	if _, err := s.client.UpdateGroupRequestParticipants(ctx, group, []types.JID{requester}, action); err != nil {
+		return fmt.Errorf("whatsapp: approving join request for %s in %s: %w", requester, group, err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package whatsapp
+
+import (
+	"sync"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// lastMessageStore remembers the most recent message seen in each chat,
+// so a later history-sync request has a reference point to backfill
+// from without re-fetching it.
+type lastMessageStore struct {
+	mu     sync.RWMutex
+	byChat map[string]*types.MessageInfo
+}
+
+func newLastMessageStore() *lastMessageStore {
+	return &lastMessageStore{byChat: make(map[string]*types.MessageInfo)}
+}
+
+func (l *lastMessageStore) record(info types.MessageInfo) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.byChat[info.Chat.String()] = &info
+}
+
+func (l *lastMessageStore) get(chat types.JID) (*types.MessageInfo, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	info, ok := l.byChat[chat.String()]
+	return info, ok
+}
@@ -0,0 +1,38 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// UpdateLiveLocation sends a follow-up position for a live location
+// share previously started with messageID.
+func (s *Session) UpdateLiveLocation(ctx context.Context, chat, messageID string, loc Location) (string, error) {
+	target, err := jid(chat)
+	if err != nil {
+		return "", err
+	}
+	if messageID == "" {
+		return "", fmt.Errorf("whatsapp: live location update requires the original message ID")
+	}
+
+	return s.sendLiveLocationUpdate(ctx, target, messageID, loc)
+}
+
+// sendLiveLocationUpdate sends loc as an edit of the live location
+// message identified by messageID, matching how WhatsApp clients push
+// follow-up positions onto an existing live share rather than starting
+// a new one.
+func (s *Session) sendLiveLocationUpdate(ctx context.Context, target types.JID, messageID string, loc Location) (string, error) {
+	msg := s.client.BuildEdit(target, messageID, buildLocationMessage(loc, true))
+
+	resp, err := s.client.SendMessage(ctx, target, msg)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: live location update for %s in %s: %w", messageID, target, err)
+	}
+
+	s.sentMessages.record(resp.ID, resp.Timestamp)
+	return resp.ID, nil
+}
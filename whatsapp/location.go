@@ -0,0 +1,177 @@
+package whatsapp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Location is a single point shared over WhatsApp, either as a static
+// pin or a snapshot of a live location share.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+
+	// Accuracy is the horizontal accuracy of the fix, in meters. Zero
+	// means unknown.
+	Accuracy float64
+	// Speed is the reporting device's speed in meters/second, only
+	// meaningful for live location shares. Zero means unknown.
+	Speed float64
+	// Heading is the reporting device's compass bearing in degrees
+	// (0-360, 0 = north), only meaningful for live location shares.
+	// Zero means unknown.
+	Heading float64
+}
+
+// IncomingLocation is a Location received from WhatsApp, attached to
+// the message that carried it.
+type IncomingLocation struct {
+	Location
+	MessageID string
+	// Live is true when the location message is flagged as a live
+	// share. Follow-up position updates of a live share are not
+	// turned into further IncomingLocations.
+	Live bool
+}
+
+// geoURIPrefix is the scheme WhatsApp/XMPP clients use to embed
+// coordinates in a plain-text message body, e.g. "geo:48.858,2.294".
+const geoURIPrefix = "geo:"
+
+// parseGeoURI extracts a Location from a "geo:" URI as commonly shared
+// by XMPP clients (RFC 5870, ignoring any optional parameters after a
+// ';'). It returns false unless body, trimmed of surrounding
+// whitespace, starts with a recognizable geo URI.
+func parseGeoURI(body string) (Location, bool) {
+	body = strings.TrimSpace(body)
+	if !strings.HasPrefix(body, geoURIPrefix) {
+		return Location{}, false
+	}
+
+	coords := strings.TrimPrefix(body, geoURIPrefix)
+	if i := strings.IndexByte(coords, ';'); i >= 0 {
+		coords = coords[:i]
+	}
+
+	parts := strings.SplitN(coords, ",", 3)
+	if len(parts) < 2 {
+		return Location{}, false
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return Location{}, false
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return Location{}, false
+	}
+
+	return Location{Latitude: lat, Longitude: lon}, true
+}
+
+// applyLocationFromBody rewrites req in place, moving a "geo:" URI (or
+// a WhatsApp-recognizable Google Maps/OSM share link) found in the
+// message body into req.Location, mirroring how incoming location
+// messages are represented on the receiving side.
+func applyLocationFromBody(req *SendRequest) {
+	if req.Location != nil {
+		return
+	}
+	if loc, ok := parseGeoURI(req.Body); ok {
+		req.Location = &loc
+		req.Body = ""
+		return
+	}
+	if loc, ok := parseMapLink(req.Body); ok {
+		req.Location = &loc
+		req.Body = ""
+	}
+}
+
+// parseMapLink recognizes the coordinate pair embedded in common
+// Google Maps and OpenStreetMap share URLs, e.g.
+// "https://maps.google.com/?q=48.858,2.294" or
+// "https://www.openstreetmap.org/?mlat=48.858&mlon=2.294".
+func parseMapLink(body string) (Location, bool) {
+	body = strings.TrimSpace(body)
+	switch {
+	case strings.Contains(body, "maps.google.") || strings.Contains(body, "google.com/maps"):
+		if i := strings.Index(body, "q="); i >= 0 {
+			return parseCoordPair(body[i+2:], ",")
+		}
+	case strings.Contains(body, "openstreetmap.org"):
+		lat, latOK := queryParam(body, "mlat")
+		lon, lonOK := queryParam(body, "mlon")
+		if latOK && lonOK {
+			return combineCoords(lat, lon)
+		}
+	}
+	return Location{}, false
+}
+
+func parseCoordPair(s, sep string) (Location, bool) {
+	if i := strings.IndexAny(s, "&#"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.SplitN(s, sep, 2)
+	if len(parts) != 2 {
+		return Location{}, false
+	}
+	return combineCoords(parts[0], parts[1])
+}
+
+func combineCoords(lat, lon string) (Location, bool) {
+	latF, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return Location{}, false
+	}
+	lonF, err := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	if err != nil {
+		return Location{}, false
+	}
+	return Location{Latitude: latF, Longitude: lonF}, true
+}
+
+func queryParam(url, key string) (string, bool) {
+	marker := key + "="
+	i := strings.Index(url, marker)
+	if i < 0 {
+		return "", false
+	}
+	rest := url[i+len(marker):]
+	if j := strings.IndexAny(rest, "&#"); j >= 0 {
+		rest = rest[:j]
+	}
+	return rest, rest != ""
+}
+
+// DebugParseLocation exposes the geo-URI/map-link parser used
+// internally by SendMessage, for diagnostic tooling outside a live
+// session.
+func DebugParseLocation(body string) (Location, bool) {
+	if loc, ok := parseGeoURI(body); ok {
+		return loc, true
+	}
+	return parseMapLink(body)
+}
+
+// String renders l as a "geo:" URI as defined by RFC 5870.
+func (l Location) String() string {
+	return fmt.Sprintf("geo:%g,%g", l.Latitude, l.Longitude)
+}
+
+// GoogleMapsURL returns a human-clickable Google Maps link for l, for
+// clients that don't render "geo:" URIs.
+func (l Location) GoogleMapsURL() string {
+	return fmt.Sprintf("https://maps.google.com/?q=%g,%g", l.Latitude, l.Longitude)
+}
+
+// FallbackBody renders l as a message body for XMPP clients without
+// native location support: the "geo:" URI clients that do understand
+// it can act on, followed by a plain Google Maps link everyone else
+// can open.
+func (l IncomingLocation) FallbackBody() string {
+	return fmt.Sprintf("%s\n%s", l.String(), l.GoogleMapsURL())
+}
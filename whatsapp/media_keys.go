@@ -0,0 +1,40 @@
+package whatsapp
+
+import "sync"
+
+// MediaKey holds everything needed to re-download and decrypt a piece
+// of WhatsApp media after the fact, without keeping the decrypted
+// bytes around.
+type MediaKey struct {
+	URL           string
+	DirectPath    string
+	MediaKey      []byte
+	FileSHA256    []byte
+	FileEncSHA256 []byte
+	FileLength    uint64
+}
+
+// mediaKeyStore escrows MediaKeys by message ID so a later re-download
+// (e.g. after a user asks to retry a failed attachment) doesn't need
+// the original event.
+type mediaKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]MediaKey
+}
+
+func newMediaKeyStore() *mediaKeyStore {
+	return &mediaKeyStore{keys: make(map[string]MediaKey)}
+}
+
+func (m *mediaKeyStore) put(messageID string, key MediaKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[messageID] = key
+}
+
+func (m *mediaKeyStore) get(messageID string) (MediaKey, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	key, ok := m.keys[messageID]
+	return key, ok
+}
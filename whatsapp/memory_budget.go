@@ -0,0 +1,50 @@
+package whatsapp
+
+import (
+	"fmt"
+	"sync"
+)
+
+// memoryBudget caps how many bytes of attachment data may be held in
+// memory at once across concurrent conversions, so a burst of large
+// incoming media doesn't exhaust the process's memory.
+type memoryBudget struct {
+	mu        sync.Mutex
+	limit     int64
+	allocated int64
+}
+
+// defaultAttachmentMemoryBudget is used when no explicit limit is
+// configured, sized to tolerate a handful of full-resolution photos or
+// a single short video in flight at once.
+const defaultAttachmentMemoryBudget = 256 * 1024 * 1024
+
+func newMemoryBudget(limit int64) *memoryBudget {
+	if limit <= 0 {
+		limit = defaultAttachmentMemoryBudget
+	}
+	return &memoryBudget{limit: limit}
+}
+
+// reserve accounts for size bytes against the budget, returning an
+// error if doing so would exceed the configured limit.
+func (b *memoryBudget) reserve(size int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.allocated+size > b.limit {
+		return fmt.Errorf("whatsapp: attachment memory budget exceeded (%d/%d bytes in flight)", b.allocated, b.limit)
+	}
+	b.allocated += size
+	return nil
+}
+
+// release returns size bytes to the budget once the attachment they
+// backed has been processed or discarded.
+func (b *memoryBudget) release(size int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.allocated -= size
+	if b.allocated < 0 {
+		b.allocated = 0
+	}
+}
@@ -0,0 +1,286 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// SendRequest describes a single outgoing message. Exactly one of Body,
+// a reaction, or an edit should typically be set; the fields are kept
+// on one struct because most of them are optional modifiers on top of
+// a plain text message.
+type SendRequest struct {
+	Chat string
+	Body string
+
+	// ReplyID, when set, marks this message as a reply/quote of the
+	// message with that ID.
+	ReplyID string
+
+	// ReplyAttachment is the quoted message's own attachment. Validate
+	// requires ReplyID when it is set, but it is not included in the
+	// sent message: the reply carries only ReplyID as its quote.
+	ReplyAttachment *Attachment
+
+	// ReactionTargetID and ReactionEmoji together send a reaction to
+	// an existing message. Both must be set, or neither.
+	ReactionTargetID string
+	ReactionEmoji    string
+
+	// EditID, when set, edits the existing message with that ID to
+	// have Body as its new content.
+	EditID string
+
+	// RetractID, when set, requests deletion of the given message ID
+	// for everyone.
+	RetractID string
+
+	AttachmentKind AttachmentKind
+	Attachments    []Attachment
+
+	// Location, when set, sends a location share instead of the text
+	// in Body.
+	Location *Location
+
+	// LiveLocation, when true alongside Location, sends it as a live
+	// (continuously updating) share instead of a static pin.
+	LiveLocation bool
+
+	// Preview is link-preview metadata for a URL in Body. If nil and
+	// Body contains a URL, SendMessage generates one itself.
+	Preview *URLPreview
+
+	// Voice, when set alongside an AttachmentKindAudio attachment,
+	// requests speed/trim preprocessing on the audio before it's sent
+	// as a voice note.
+	Voice *VoicePreprocessing
+}
+
+// Validate checks req for internally-inconsistent combinations of
+// fields before any network activity is attempted, returning a
+// descriptive error naming the offending field(s).
+func (req SendRequest) Validate() error {
+	if req.Chat == "" {
+		return fmt.Errorf("whatsapp: send request missing chat")
+	}
+
+	if req.LiveLocation && req.Location == nil {
+		return fmt.Errorf("whatsapp: live location flag set without a location")
+	}
+
+	if req.ReplyAttachment != nil && req.ReplyID == "" {
+		return fmt.Errorf("whatsapp: reply attachment set without a reply target message ID")
+	}
+
+	hasReaction := req.ReactionTargetID != "" || req.ReactionEmoji != ""
+	if hasReaction && (req.ReactionTargetID == "" || req.ReactionEmoji == "") {
+		return fmt.Errorf("whatsapp: reaction requires both a target message ID and an emoji")
+	}
+
+	if req.EditID != "" && req.Body == "" {
+		return fmt.Errorf("whatsapp: edit of message %s requires a non-empty body", req.EditID)
+	}
+
+	if req.AttachmentKind != AttachmentKindNone && len(req.Attachments) == 0 {
+		return fmt.Errorf("whatsapp: attachment kind set without any attachments")
+	}
+	if req.AttachmentKind == AttachmentKindNone && len(req.Attachments) > 0 {
+		return fmt.Errorf("whatsapp: attachments present without an attachment kind")
+	}
+	if req.AttachmentKind == AttachmentKindSticker {
+		if len(req.Attachments) != 1 {
+			return fmt.Errorf("whatsapp: sticker messages take exactly one attachment")
+		}
+		if mime := req.Attachments[0].MIMEType; mime != "" && mime != "image/webp" {
+			return fmt.Errorf("whatsapp: sticker attachment must be image/webp, got %s", mime)
+		}
+	}
+
+	exclusive := 0
+	for _, set := range []bool{hasReaction, req.EditID != "", req.RetractID != ""} {
+		if set {
+			exclusive++
+		}
+	}
+	if exclusive > 1 {
+		return fmt.Errorf("whatsapp: reaction, edit and retract are mutually exclusive on a single send request")
+	}
+
+	if req.Voice != nil {
+		if req.AttachmentKind != AttachmentKindAudio {
+			return fmt.Errorf("whatsapp: voice preprocessing requires an audio attachment")
+		}
+		if err := req.Voice.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SendMessage validates req and, if valid, dispatches it to WhatsApp.
+func (s *Session) SendMessage(ctx context.Context, req SendRequest) (string, error) {
+	applyLocationFromBody(&req)
+	req.Body = convertStylingToWhatsApp(req.Body)
+
+	if err := req.Validate(); err != nil {
+		return "", err
+	}
+
+	if req.Preview == nil && req.Location == nil {
+		if url, ok := firstURL(req.Body); ok {
+			if preview, err := GenerateURLPreview(ctx, url); err == nil {
+				req.Preview = &preview
+			}
+		}
+	}
+
+	chat, err := jid(req.Chat)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.quota.allow(chat.String(), time.Now()); err != nil {
+		return "", err
+	}
+
+	if req.EditID != "" && chat.Server == types.GroupServer {
+		if err := s.checkCanEditGroupMessage(chat, req.EditID); err != nil {
+			return "", err
+		}
+	}
+
+	return s.sendValidated(ctx, chat, req)
+}
+
+// checkCanEditGroupMessage rejects an edit of a message we didn't send
+// ourselves in a group: WhatsApp only lets the original author edit a
+// message, regardless of admin/delete-for-everyone permissions, so
+// failing fast here avoids a confusing server rejection later.
+func (s *Session) checkCanEditGroupMessage(chat types.JID, messageID string) error {
+	ourMessage, err := s.weSentMessage(chat, messageID)
+	if err != nil {
+		return err
+	}
+	if !ourMessage {
+		return fmt.Errorf("whatsapp: cannot edit message %s: only the original sender may edit a group message", messageID)
+	}
+	return nil
+}
+
+// weSentMessage reports whether messageID in chat was sent by us,
+// consulting the session's own sent-message tracking.
+func (s *Session) weSentMessage(chat types.JID, messageID string) (bool, error) {
+	_, ok := s.sentMessages.sentAt(messageID)
+	return ok, nil
+}
+
+// sendValidated performs the actual send once req has passed
+// validation, building the appropriate protobuf message for req's kind
+// and dispatching it via the underlying whatsmeow client.
+func (s *Session) sendValidated(ctx context.Context, chat types.JID, req SendRequest) (string, error) {
+	msg, err := s.buildOutgoingMessage(ctx, chat, req)
+	if err != nil {
+		return "", err
+	}
+
+	s.runOutgoingMessageDebugHooks(chat, msg)
+
+	resp, err := s.client.SendMessage(ctx, chat, msg)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: send to %s: %w", chat, err)
+	}
+
+	s.sentMessages.record(resp.ID, resp.Timestamp)
+	return resp.ID, nil
+}
+
+// buildOutgoingMessage renders req as the *waE2E.Message whatsmeow
+// should transmit, dispatching to the builder for whichever request
+// kind req actually is; req.Validate has already ensured these kinds
+// are mutually exclusive.
+func (s *Session) buildOutgoingMessage(ctx context.Context, chat types.JID, req SendRequest) (*waE2E.Message, error) {
+	switch {
+	case req.RetractID != "":
+		return s.client.BuildRevoke(chat, types.EmptyJID, req.RetractID), nil
+	case req.EditID != "":
+		return s.client.BuildEdit(chat, req.EditID, &waE2E.Message{Conversation: proto.String(req.Body)}), nil
+	case req.ReactionTargetID != "":
+		return s.buildReactionMessage(chat, req), nil
+	case req.Location != nil:
+		return buildLocationMessage(*req.Location, req.LiveLocation), nil
+	case len(req.Attachments) > 0:
+		return s.buildAttachmentMessage(ctx, req)
+	default:
+		return buildTextMessage(req), nil
+	}
+}
+
+// buildReactionMessage builds a reaction to req.ReactionTargetID,
+// looking up whether that message was one of ours so the reaction's
+// key correctly identifies its origin.
+func (s *Session) buildReactionMessage(chat types.JID, req SendRequest) *waE2E.Message {
+	fromMe, _ := s.weSentMessage(chat, req.ReactionTargetID)
+	return &waE2E.Message{
+		ReactionMessage: &waE2E.ReactionMessage{
+			Key: &waCommon.MessageKey{
+				RemoteJID: proto.String(chat.String()),
+				FromMe:    proto.Bool(fromMe),
+				ID:        proto.String(req.ReactionTargetID),
+			},
+			Text:              proto.String(req.ReactionEmoji),
+			SenderTimestampMS: proto.Int64(time.Now().UnixMilli()),
+		},
+	}
+}
+
+// buildTextMessage builds a plain text message, upgrading to an
+// extended text message when a reply quote or link preview is present,
+// since Conversation carries neither.
+func buildTextMessage(req SendRequest) *waE2E.Message {
+	if req.ReplyID == "" && req.Preview == nil {
+		return &waE2E.Message{Conversation: proto.String(req.Body)}
+	}
+
+	ext := &waE2E.ExtendedTextMessage{Text: proto.String(req.Body)}
+	if req.ReplyID != "" {
+		ext.ContextInfo = &waE2E.ContextInfo{StanzaID: proto.String(req.ReplyID)}
+	}
+	if req.Preview != nil {
+		ext.MatchedText = proto.String(req.Preview.URL)
+		ext.Title = proto.String(req.Preview.Title)
+		ext.Description = proto.String(req.Preview.Description)
+		if req.Preview.IsVideo {
+			ext.PreviewType = waE2E.ExtendedTextMessage_VIDEO.Enum()
+		}
+	}
+	return &waE2E.Message{ExtendedTextMessage: ext}
+}
+
+// buildLocationMessage builds a static or live location share.
+func buildLocationMessage(loc Location, live bool) *waE2E.Message {
+	if live {
+		return &waE2E.Message{
+			LiveLocationMessage: &waE2E.LiveLocationMessage{
+				DegreesLatitude:                   proto.Float64(loc.Latitude),
+				DegreesLongitude:                  proto.Float64(loc.Longitude),
+				SpeedInMps:                        proto.Float32(float32(loc.Speed)),
+				DegreesClockwiseFromMagneticNorth: proto.Uint32(uint32(loc.Heading)),
+			},
+		}
+	}
+	return &waE2E.Message{
+		LocationMessage: &waE2E.LocationMessage{
+			DegreesLatitude:  proto.Float64(loc.Latitude),
+			DegreesLongitude: proto.Float64(loc.Longitude),
+			AccuracyInMeters: proto.Uint32(uint32(loc.Accuracy)),
+			SpeedInMps:       proto.Float32(float32(loc.Speed)),
+		},
+	}
+}
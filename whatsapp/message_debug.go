@@ -0,0 +1,23 @@
+package whatsapp
+
+import (
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// OutgoingMessageDebugHook receives the raw protobuf whatsmeow sends
+// for an outgoing message, before it's transmitted, so callers can log
+// or inspect the wire format without a packet capture.
+type OutgoingMessageDebugHook func(chat types.JID, msg *waE2E.Message)
+
+// AddOutgoingMessageDebugHook registers hook to run on every message
+// SendMessage builds, in registration order.
+func (s *Session) AddOutgoingMessageDebugHook(hook OutgoingMessageDebugHook) {
+	s.outgoingDebugHooks = append(s.outgoingDebugHooks, hook)
+}
+
+func (s *Session) runOutgoingMessageDebugHooks(chat types.JID, msg *waE2E.Message) {
+	for _, hook := range s.outgoingDebugHooks {
+		hook(chat, msg)
+	}
+}
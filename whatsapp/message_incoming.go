@@ -0,0 +1,319 @@
+package whatsapp
+
+import (
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// IncomingMessage is the normalized shape of a message received from
+// WhatsApp, ready to be relayed to XMPP.
+type IncomingMessage struct {
+	ID        string
+	Chat      string
+	From      string
+	FromName  string
+	Body      string
+	Timestamp time.Time
+
+	Location *IncomingLocation
+	Sticker  *Attachment
+	Payment  *PaymentNotification
+	Order    *OrderNotification
+	Product  *ProductMessage
+
+	// ReplyToID and ReplyThumbnail describe the quoted message when
+	// this message is a reply, ReplyThumbnail being a small preview of
+	// the quoted message's own attachment, if it had one.
+	ReplyToID      string
+	ReplyThumbnail *Attachment
+
+	// EphemeralExpiration is non-zero when the message is subject to
+	// WhatsApp's disappearing-message timer for its chat.
+	EphemeralExpiration time.Duration
+}
+
+// fromMessageEvent converts a whatsmeow *events.Message into an
+// IncomingMessage, filling Body from whichever content type the
+// message actually carries.
+func fromMessageEvent(evt *events.Message) IncomingMessage {
+	msg := IncomingMessage{
+		ID:   evt.Info.ID,
+		Chat: normalizeJID(evt.Info.Chat).String(),
+		From: normalizeJID(evt.Info.Sender).String(),
+	}
+
+	if text := evt.Message.GetConversation(); text != "" {
+		msg.Body = text
+	} else if text := evt.Message.GetExtendedTextMessage().GetText(); text != "" {
+		msg.Body = text
+	} else if caption := evt.Message.GetImageMessage().GetCaption(); caption != "" {
+		msg.Body = caption
+	} else if caption := evt.Message.GetVideoMessage().GetCaption(); caption != "" {
+		msg.Body = caption
+	} else if caption := evt.Message.GetDocumentMessage().GetCaption(); caption != "" {
+		msg.Body = caption
+	}
+
+	if ctxInfo := messageContextInfo(evt.Message); ctxInfo != nil {
+		msg.ReplyToID = ctxInfo.GetStanzaID()
+		if quoted := ctxInfo.GetQuotedMessage(); quoted != nil {
+			msg.ReplyThumbnail = quotedAttachmentThumbnail(quoted)
+		}
+		if exp := ctxInfo.GetExpiration(); exp > 0 {
+			msg.EphemeralExpiration = time.Duration(exp) * time.Second
+		}
+	}
+
+	if loc := evt.Message.GetLocationMessage(); loc != nil {
+		l := IncomingLocation{
+			Location: Location{
+				Latitude:  loc.GetDegreesLatitude(),
+				Longitude: loc.GetDegreesLongitude(),
+				Accuracy:  float64(loc.GetAccuracyInMeters()),
+				Speed:     float64(loc.GetSpeedInMps()),
+				Heading:   float64(loc.GetDegreesClockwiseFromMagneticNorth()),
+			},
+			MessageID: evt.Info.ID,
+			Live:      loc.GetIsLive(),
+		}
+		msg.Location = &l
+		msg.Body = l.FallbackBody()
+	}
+
+	if buttons := evt.Message.GetButtonsMessage(); buttons != nil {
+		var options []string
+		for _, b := range buttons.GetButtons() {
+			options = append(options, b.GetButtonText().GetDisplayText())
+		}
+		msg.Body = renderInteractive(InteractiveMessage{Body: buttons.GetContentText(), Buttons: options})
+	}
+
+	if template := evt.Message.GetTemplateMessage(); template != nil {
+		content := template.GetHydratedTemplate()
+		var options []string
+		for _, b := range content.GetHydratedButtons() {
+			if quick := b.GetQuickReplyButton(); quick != nil {
+				options = append(options, quick.GetDisplayText())
+			} else if url := b.GetUrlButton(); url != nil {
+				options = append(options, url.GetDisplayText())
+			}
+		}
+		msg.Body = renderInteractive(InteractiveMessage{Body: content.GetHydratedContentText(), Buttons: options})
+	}
+
+	if list := evt.Message.GetListMessage(); list != nil {
+		var options []string
+		for _, section := range list.GetSections() {
+			for _, row := range section.GetRows() {
+				options = append(options, row.GetTitle())
+			}
+		}
+		msg.Body = renderInteractive(InteractiveMessage{Body: list.GetDescription(), Buttons: options})
+	}
+
+	if payment := evt.Message.GetRequestPaymentMessage(); payment != nil {
+		msg.Payment = &PaymentNotification{
+			MessageID: evt.Info.ID,
+			From:      msg.From,
+			Amount:    int64(payment.GetAmount1000() / 1000),
+			Currency:  payment.GetCurrencyCodeIso4217(),
+			Note:      payment.GetNoteMessage().GetExtendedTextMessage().GetText(),
+		}
+	}
+
+	if product := evt.Message.GetProductMessage(); product != nil {
+		p := product.GetProduct()
+		msg.Product = &ProductMessage{
+			MessageID: evt.Info.ID,
+			From:      msg.From,
+			Product: Product{
+				ID:          p.GetProductID(),
+				Name:        p.GetTitle(),
+				Description: p.GetDescription(),
+				Currency:    p.GetCurrencyCode(),
+				Price:       p.GetPriceAmount1000() / 1000,
+			},
+		}
+	}
+
+	if order := evt.Message.GetOrderMessage(); order != nil {
+		msg.Order = &OrderNotification{
+			MessageID: evt.Info.ID,
+			From:      msg.From,
+			OrderID:   order.GetOrderID(),
+			ItemCount: int(order.GetItemCount()),
+			Total:     order.GetTotalAmount1000() / 1000,
+			Currency:  order.GetTotalCurrencyCode(),
+		}
+	}
+
+	if sticker := evt.Message.GetStickerMessage(); sticker != nil {
+		att := IncomingSticker{
+			MessageID: evt.Info.ID,
+			MIMEType:  sticker.GetMimetype(),
+			Animated:  sticker.GetIsAnimated(),
+		}.displayAttachment()
+		msg.Sticker = &att
+	}
+
+	return msg
+}
+
+// editedBody extracts the new text for an edited message, falling back
+// to the caption field when the edit targets a media message's
+// caption rather than a plain text body.
+func editedBody(edited *waE2E.Message) string {
+	if edited == nil {
+		return ""
+	}
+	if text := edited.GetConversation(); text != "" {
+		return text
+	}
+	if text := edited.GetExtendedTextMessage().GetText(); text != "" {
+		return text
+	}
+	if caption := edited.GetImageMessage().GetCaption(); caption != "" {
+		return caption
+	}
+	if caption := edited.GetVideoMessage().GetCaption(); caption != "" {
+		return caption
+	}
+	return ""
+}
+
+// messageContextInfo extracts the ContextInfo carrying reply/quote data
+// from whichever content type msg actually is; extended-text, image,
+// video and document messages are checked, others yield nil.
+func messageContextInfo(msg *waE2E.Message) *waE2E.ContextInfo {
+	if text := msg.GetExtendedTextMessage(); text != nil {
+		return text.GetContextInfo()
+	}
+	if img := msg.GetImageMessage(); img != nil {
+		return img.GetContextInfo()
+	}
+	if vid := msg.GetVideoMessage(); vid != nil {
+		return vid.GetContextInfo()
+	}
+	if doc := msg.GetDocumentMessage(); doc != nil {
+		return doc.GetContextInfo()
+	}
+	return nil
+}
+
+// albumAssociation reports the album ID msg declares membership in, if
+// any: WhatsApp marks each item of an album with a MessageAssociation
+// on its MessageContextInfo pointing back at the album header
+// message's ID.
+func albumAssociation(msg *waE2E.Message) (string, bool) {
+	assoc := msg.GetMessageContextInfo().GetMessageAssociation()
+	if assoc == nil || assoc.GetAssociationType() != waE2E.MessageAssociation_MEDIA_ALBUM {
+		return "", false
+	}
+	return assoc.GetParentMessageKey().GetID(), true
+}
+
+// incomingAlbumAttachment extracts the media attachment out of an album
+// member message; images and videos are the only kinds WhatsApp allows
+// in an album.
+func incomingAlbumAttachment(msg *waE2E.Message) (Attachment, bool) {
+	if img := msg.GetImageMessage(); img != nil {
+		return Attachment{Kind: AttachmentKindImage, MIMEType: img.GetMimetype()}, true
+	}
+	if vid := msg.GetVideoMessage(); vid != nil {
+		return Attachment{Kind: AttachmentKindVideo, MIMEType: vid.GetMimetype()}, true
+	}
+	return Attachment{}, false
+}
+
+// quotedAttachmentThumbnail pulls the small embedded JPEG thumbnail out
+// of a quoted image, video or document message, if it has one, so a
+// reply can show
+// a preview of what it's replying to without re-downloading the
+// original attachment.
+func quotedAttachmentThumbnail(quoted *waE2E.Message) *Attachment {
+	var jpegThumb []byte
+	var mime string
+	switch {
+	case quoted.GetImageMessage() != nil:
+		jpegThumb, mime = quoted.GetImageMessage().GetJPEGThumbnail(), "image/jpeg"
+	case quoted.GetVideoMessage() != nil:
+		jpegThumb, mime = quoted.GetVideoMessage().GetJPEGThumbnail(), "image/jpeg"
+	case quoted.GetDocumentMessage() != nil:
+		jpegThumb, mime = quoted.GetDocumentMessage().GetJPEGThumbnail(), "image/jpeg"
+	}
+	if len(jpegThumb) == 0 {
+		return nil
+	}
+	return &Attachment{Kind: AttachmentKindImage, MIMEType: mime, Data: jpegThumb, Filename: "quoted-thumbnail.jpg"}
+}
+
+// handleMessageEvent normalizes evt and, if it carries a
+// disappearing-message timer, schedules the bridged copy for deletion
+// once that timer fires.
+func (s *Session) handleMessageEvent(evt *events.Message) {
+	s.lastMessages.record(evt.Info)
+
+	if s.config.SelfMessageMode == SelfMessageModeIgnore && evt.Info.IsFromMe && evt.Info.Chat.User == s.client.Store.ID.User {
+		return
+	}
+
+	if evt.Info.IsFromMe && s.isRecentOwnCarbon(evt.Info.ID) {
+		return
+	}
+
+	if s.isSuppressedBotChat(normalizeJID(evt.Info.Sender).String()) {
+		return
+	}
+
+	if evt.Info.Chat == types.StatusBroadcastJID {
+		s.handleStatusMessageEvent(normalizeJID(evt.Info.Sender).String(), evt.Message.GetConversation())
+		return
+	}
+
+	if album := evt.Message.GetAlbumMessage(); album != nil {
+		count := int(album.GetExpectedImageCount() + album.GetExpectedVideoCount())
+		s.albums.start(normalizeJID(evt.Info.Chat).String(), evt.Info.ID, count)
+		return
+	}
+
+	if albumID, ok := albumAssociation(evt.Message); ok {
+		if att, ok := incomingAlbumAttachment(evt.Message); ok {
+			if album, complete := s.albums.add(albumID, att); complete {
+				s.handleEvent(&album)
+			}
+			return
+		}
+	}
+
+	if proto := evt.Message.GetProtocolMessage(); proto != nil {
+		if proto.GetType() == waE2E.ProtocolMessage_MESSAGE_EDIT {
+			s.handleEditMessage(normalizeJID(evt.Info.Chat).String(), proto.GetKey().GetID(), editedBody(proto.GetEditedMessage()))
+			return
+		}
+		s.handleProtocolMessage(
+			normalizeJID(evt.Info.Chat).String(),
+			normalizeJID(evt.Info.Sender).String(),
+			proto.GetEphemeralExpiration(),
+			proto.GetType() == waE2E.ProtocolMessage_EPHEMERAL_SETTING,
+		)
+		return
+	}
+
+	msg := fromMessageEvent(evt)
+	msg.Timestamp = s.timestampIn(evt.Info.Timestamp)
+	if evt.Info.Chat.Server == types.GroupServer {
+		msg.FromName = s.contactName(evt.Info.Sender)
+	}
+	if msg.EphemeralExpiration > 0 {
+		s.scheduleEphemeralDelete(msg.Chat, msg.ID, evt.Info.Timestamp, msg.EphemeralExpiration)
+	}
+
+	msg, ok := s.applyMessageTransforms(msg)
+	if !ok {
+		return
+	}
+	s.handleEvent(&msg)
+}
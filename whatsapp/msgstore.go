@@ -0,0 +1,40 @@
+package whatsapp
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// ImportMsgstore seeds history by reading an Android WhatsApp
+// "msgstore.db" backup (a plain, un-encrypted SQLite export) at path,
+// returning the messages it contains in chronological order.
+func ImportMsgstore(path string) ([]IncomingMessage, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("whatsapp: open msgstore: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT key_id, key_remote_jid, key_from_me, data FROM messages ORDER BY timestamp ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("whatsapp: query msgstore: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []IncomingMessage
+	for rows.Next() {
+		var msg IncomingMessage
+		var fromMe int
+		if err := rows.Scan(&msg.ID, &msg.Chat, &fromMe, &msg.Body); err != nil {
+			return nil, fmt.Errorf("whatsapp: scan msgstore row: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("whatsapp: read msgstore rows: %w", err)
+	}
+
+	return messages, nil
+}
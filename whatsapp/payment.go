@@ -0,0 +1,22 @@
+package whatsapp
+
+// PaymentNotification is a payment confirmation/request WhatsApp sends
+// as a special message type.
+type PaymentNotification struct {
+	MessageID string
+	From      string
+	Amount    int64
+	Currency  string
+	Note      string
+}
+
+// OrderNotification is a business order notification (items, total),
+// sent by a business account chat.
+type OrderNotification struct {
+	MessageID string
+	From      string
+	OrderID   string
+	ItemCount int
+	Total     int64
+	Currency  string
+}
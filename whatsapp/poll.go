@@ -0,0 +1,86 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Poll is a WhatsApp poll: a question with a fixed set of options that
+// contacts vote on by name.
+type Poll struct {
+	Name    string
+	Options []string
+
+	// SelectableCount is how many options a voter may pick; 1 for a
+	// single-answer poll.
+	SelectableCount int
+}
+
+// IncomingPollVote is a single vote update for a poll message, giving
+// the full current selection (WhatsApp always reports the complete set
+// of chosen options, not a delta).
+type IncomingPollVote struct {
+	PollMessageID string
+	VoterJID      string
+	SelectedNames []string
+}
+
+// SendPoll sends a new poll to chat.
+func (s *Session) SendPoll(ctx context.Context, chat string, poll Poll) (string, error) {
+	if poll.Name == "" {
+		return "", fmt.Errorf("whatsapp: poll requires a question")
+	}
+	if len(poll.Options) < 2 {
+		return "", fmt.Errorf("whatsapp: poll requires at least two options")
+	}
+	if poll.SelectableCount < 0 || poll.SelectableCount > len(poll.Options) {
+		return "", fmt.Errorf("whatsapp: poll selectable count must be between 1 and the number of options")
+	}
+	if poll.SelectableCount == 0 {
+		poll.SelectableCount = 1
+	}
+
+	target, err := jid(chat)
+	if err != nil {
+		return "", err
+	}
+
+	return s.sendPollToJID(ctx, target, poll)
+}
+
+func (s *Session) sendPollToJID(ctx context.Context, target types.JID, poll Poll) (string, error) {
+	msg := s.client.BuildPollCreation(poll.Name, poll.Options, poll.SelectableCount)
+
+	resp, err := s.client.SendMessage(ctx, target, msg)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: send poll to %s: %w", target, err)
+	}
+	s.sentMessages.record(resp.ID, resp.Timestamp)
+	return resp.ID, nil
+}
+
+// fromPollCreationMessage converts an incoming poll v3 creation message
+// into a Poll, translating its selectableOptionsCount (0 meaning
+// "unlimited", per the WhatsApp protocol) into our SelectableCount
+// convention where 0 always means single-answer.
+func fromPollCreationMessage(name string, optionNames []string, selectableOptionsCount uint32) Poll {
+	selectable := int(selectableOptionsCount)
+	if selectable == 0 {
+		selectable = len(optionNames)
+	}
+	return Poll{Name: name, Options: optionNames, SelectableCount: selectable}
+}
+
+// fromPollUpdateEvent converts a whatsmeow poll vote update into an
+// IncomingPollVote once the vote's options have been decrypted and
+// matched back to their display names by the caller.
+func fromPollUpdateEvent(evt *events.Message, selectedNames []string) IncomingPollVote {
+	return IncomingPollVote{
+		PollMessageID: evt.Info.ID,
+		VoterJID:      normalizeJID(evt.Info.Sender).String(),
+		SelectedNames: selectedNames,
+	}
+}
@@ -0,0 +1,95 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// URLPreview is the Open Graph-derived preview metadata attached to an
+// outgoing link.
+type URLPreview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	// IsVideo marks url as pointing to playable video content, so
+	// WhatsApp renders the preview with a play button overlay.
+	IsVideo bool
+}
+
+// videoHosts are domains whose links are always video content, even
+// without fetching the page to check its Open Graph type.
+var videoHosts = []string{"youtube.com", "youtu.be", "vimeo.com", "tiktok.com"}
+
+// isVideoURL reports whether url points to a known video host.
+func isVideoURL(url string) bool {
+	for _, host := range videoHosts {
+		if strings.Contains(url, host) {
+			return true
+		}
+	}
+	return false
+}
+
+var urlPattern = regexp.MustCompile(`https?://\S+`)
+
+// firstURL returns the first http(s) URL found in body, if any.
+func firstURL(body string) (string, bool) {
+	match := urlPattern.FindString(body)
+	return match, match != ""
+}
+
+// GenerateURLPreview fetches url and extracts Open Graph metadata for
+// it, for use when the calling XMPP client didn't already supply link
+// preview data of its own.
+func GenerateURLPreview(ctx context.Context, url string) (URLPreview, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return URLPreview{}, fmt.Errorf("whatsapp: build preview request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return URLPreview{}, fmt.Errorf("whatsapp: fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return URLPreview{}, fmt.Errorf("whatsapp: read %s: %w", url, err)
+	}
+
+	preview := URLPreview{URL: url}
+	preview.Title = openGraphTag(string(body), "og:title")
+	preview.Description = openGraphTag(string(body), "og:description")
+	preview.ImageURL = openGraphTag(string(body), "og:image")
+	preview.IsVideo = isVideoURL(url) || openGraphTag(string(body), "og:type") == "video"
+	return preview, nil
+}
+
+func openGraphTag(html, property string) string {
+	marker := `property="` + property + `"`
+	idx := strings.Index(html, marker)
+	if idx < 0 {
+		return ""
+	}
+	tagEnd := strings.Index(html[idx:], ">")
+	if tagEnd < 0 {
+		return ""
+	}
+	tag := html[idx : idx+tagEnd]
+	contentIdx := strings.Index(tag, `content="`)
+	if contentIdx < 0 {
+		return ""
+	}
+	rest := tag[contentIdx+len(`content="`):]
+	end := strings.Index(rest, `"`)
+	if end < 0 {
+		return ""
+	}
+	return rest[:end]
+}
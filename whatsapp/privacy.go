@@ -0,0 +1,72 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// PrivacySettingValue mirrors the granularity WhatsApp offers for each
+// privacy setting: visible to everyone, only contacts, or nobody.
+type PrivacySettingValue int
+
+const (
+	PrivacySettingEveryone PrivacySettingValue = iota
+	PrivacySettingContacts
+	PrivacySettingNobody
+)
+
+// PrivacySettings holds the subset of WhatsApp's account privacy
+// controls the gateway can read and change.
+type PrivacySettings struct {
+	LastSeen     PrivacySettingValue
+	ProfilePhoto PrivacySettingValue
+	About        PrivacySettingValue
+	ReadReceipts bool
+}
+
+func fromPrivacySetting(v types.PrivacySetting) PrivacySettingValue {
+	switch v {
+	case types.PrivacySettingContacts:
+		return PrivacySettingContacts
+	case types.PrivacySettingNone:
+		return PrivacySettingNobody
+	default:
+		return PrivacySettingEveryone
+	}
+}
+
+func (v PrivacySettingValue) whatsmeow() types.PrivacySetting {
+	switch v {
+	case PrivacySettingContacts:
+		return types.PrivacySettingContacts
+	case PrivacySettingNobody:
+		return types.PrivacySettingNone
+	default:
+		return types.PrivacySettingAll
+	}
+}
+
+// GetPrivacySettings fetches the account's current privacy settings.
+func (s *Session) GetPrivacySettings(ctx context.Context) (PrivacySettings, error) {
+	settings, err := s.client.TryFetchPrivacySettings(ctx, true)
+	if err != nil {
+		return PrivacySettings{}, fmt.Errorf("whatsapp: fetch privacy settings: %w", err)
+	}
+
+	return PrivacySettings{
+		LastSeen:     fromPrivacySetting(settings.LastSeen),
+		ProfilePhoto: fromPrivacySetting(settings.Profile),
+		About:        fromPrivacySetting(settings.Status),
+		ReadReceipts: settings.ReadReceipts == types.PrivacySettingAll,
+	}, nil
+}
+
+// SetPrivacySetting changes a single privacy setting to value.
+func (s *Session) SetPrivacySetting(ctx context.Context, name types.PrivacySettingType, value PrivacySettingValue) error {
+	if _, err := s.client.SetPrivacySetting(ctx, name, value.whatsmeow()); err != nil {
+		return fmt.Errorf("whatsapp: SetPrivacySetting(%s): %w", name, err)
+	}
+	return nil
+}
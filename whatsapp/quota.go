@@ -0,0 +1,57 @@
+package whatsapp
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sendQuota rate-limits outgoing messages per recipient, so a runaway
+// caller (a misbehaving XMPP client, a scripted bulk send) can't hammer
+// a single chat or exhaust the account's own abuse thresholds.
+type sendQuota struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	sent   map[string][]time.Time
+}
+
+// defaultQuotaLimit and defaultQuotaWindow bound outgoing messages to a
+// single chat to a rate well under what triggers WhatsApp's own
+// spam/abuse detection.
+const (
+	defaultQuotaLimit  = 20
+	defaultQuotaWindow = time.Minute
+)
+
+func newSendQuota(limit int, window time.Duration) *sendQuota {
+	if limit <= 0 {
+		limit = defaultQuotaLimit
+	}
+	if window <= 0 {
+		window = defaultQuotaWindow
+	}
+	return &sendQuota{limit: limit, window: window, sent: make(map[string][]time.Time)}
+}
+
+// allow reports whether a message to chat may be sent now, recording
+// the attempt if so.
+func (q *sendQuota) allow(chat string, now time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cutoff := now.Add(-q.window)
+	kept := q.sent[chat][:0]
+	for _, t := range q.sent[chat] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) >= q.limit {
+		q.sent[chat] = kept
+		retryAfter := kept[0].Add(q.window).Sub(now)
+		return &RateLimitError{Operation: fmt.Sprintf("send to %s", chat), RetryAfter: retryAfter}
+	}
+	q.sent[chat] = append(kept, now)
+	return nil
+}
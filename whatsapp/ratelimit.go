@@ -0,0 +1,18 @@
+package whatsapp
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimitError is returned by operations throttled by this session
+// (send quotas) or rejected by WhatsApp's own rate limiting, carrying
+// how long the caller should wait before retrying.
+type RateLimitError struct {
+	Operation  string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("whatsapp: %s rate-limited, retry after %s", e.Operation, e.RetryAfter)
+}
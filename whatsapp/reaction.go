@@ -0,0 +1,46 @@
+package whatsapp
+
+// ReactionReceiptEvent is emitted in place of IncomingReaction when the
+// session's ReactionFallbackToReceipt option is enabled, for XMPP
+// clients that have no way to display a reaction and would rather see
+// it as a read receipt on the reacted-to message.
+type ReactionReceiptEvent struct {
+	Chat            string
+	TargetMessageID string
+	From            string
+}
+
+// deliverReaction emits r as an IncomingReaction, or as a
+// ReactionReceiptEvent if the session is configured to fall back to
+// receipts.
+func (s *Session) deliverReaction(r IncomingReaction) {
+	if s.config.ReactionFallbackToReceipt {
+		s.handleEvent(&ReactionReceiptEvent{Chat: r.Chat, TargetMessageID: r.TargetMessageID, From: r.From})
+		return
+	}
+	s.handleEvent(&r)
+}
+
+// IncomingReaction is a reaction to a previously-sent/received message.
+type IncomingReaction struct {
+	// Chat is the chat the reacted-to message belongs to. For a
+	// carbon of our own outgoing message reacted to in a 1:1, this is
+	// the peer's JID rather than the receipt's own sender/chat fields,
+	// which whatsmeow reports relative to the reacting device.
+	Chat            string
+	TargetMessageID string
+	From            string
+	Emoji           string
+}
+
+// fromReactionMessage builds an IncomingReaction, resolving chat to the
+// reacted-to message's actual chat rather than trusting the event's own
+// chat field, which for a 1:1 carbon reaction reports our own JID
+// instead of the peer's.
+func fromReactionMessage(reportedChat, peerJID, from, targetID, emoji string) IncomingReaction {
+	chat := reportedChat
+	if from == peerJID || reportedChat == from {
+		chat = peerJID
+	}
+	return IncomingReaction{Chat: chat, TargetMessageID: targetID, From: from, Emoji: emoji}
+}
@@ -0,0 +1,29 @@
+package whatsapp
+
+import "go.mau.fi/whatsmeow/types/events"
+
+// OwnDeviceReceiptEvent is emitted when another of our own linked
+// devices sends a receipt (delivered/read) for a message, so the
+// gateway can mirror read state across devices instead of only
+// tracking receipts from the chat's other participants.
+type OwnDeviceReceiptEvent struct {
+	Chat      string
+	MessageID string
+	Type      string
+}
+
+// handleReceiptEvent forwards receipts originating from our own other
+// devices as OwnDeviceReceiptEvent, in addition to whatever normal
+// per-chat receipt handling already exists.
+func (s *Session) handleReceiptEvent(evt *events.Receipt) {
+	if !evt.IsFromMe {
+		return
+	}
+	for _, id := range evt.MessageIDs {
+		s.handleEvent(&OwnDeviceReceiptEvent{
+			Chat:      normalizeJID(evt.Chat).String(),
+			MessageID: id,
+			Type:      string(evt.Type),
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// streamReplacedBackoff is how long to wait before attempting to
+// resume a session after WhatsApp reports our stream was replaced by
+// another connection (e.g. the same account logging in elsewhere
+// briefly), so we don't fight the other connection for the socket.
+const streamReplacedBackoff = 5 * time.Second
+
+// handleStreamReplacedEvent schedules a single reconnect attempt after
+// a StreamReplaced event, giving the other connection a head start in
+// case it's short-lived (a common pattern when WhatsApp Web is opened
+// briefly on another device).
+func (s *Session) handleStreamReplacedEvent(*events.StreamReplaced) {
+	time.AfterFunc(streamReplacedBackoff, func() {
+		_ = s.Connect(context.Background())
+	})
+}
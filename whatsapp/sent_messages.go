@@ -0,0 +1,35 @@
+package whatsapp
+
+import (
+	"sync"
+	"time"
+)
+
+// sentMessageStore tracks message IDs this session originated and when,
+// to distinguish our own group messages from others' for edit/delete
+// permission checks and to bound carbon-echo suppression to a recent
+// window. It's a small mutex-guarded map rather than a plain field
+// because SendMessage now writes to it from the actual send path,
+// which can run concurrently with incoming-message handling reading
+// from it.
+type sentMessageStore struct {
+	mu   sync.RWMutex
+	sent map[string]time.Time
+}
+
+func newSentMessageStore() *sentMessageStore {
+	return &sentMessageStore{sent: make(map[string]time.Time)}
+}
+
+func (s *sentMessageStore) record(messageID string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent[messageID] = at
+}
+
+func (s *sentMessageStore) sentAt(messageID string) (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	at, ok := s.sent[messageID]
+	return at, ok
+}
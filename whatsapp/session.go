@@ -0,0 +1,179 @@
+// Package whatsapp implements the WhatsApp-facing half of the slidge
+// gateway, wrapping the whatsmeow client library with the session,
+// contact, message and group primitives the Go/Python bridge exchanges
+// over gRPC.
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Session represents a single logged-in WhatsApp account. It owns the
+// underlying whatsmeow client and translates between whatsmeow's event
+// model and the simplified types this package exposes to the rest of
+// the gateway.
+type Session struct {
+	client *whatsmeow.Client
+	config Config
+
+	// eventHandlers are invoked in registration order for every
+	// incoming Event produced by the underlying client.
+	eventHandlers []EventHandler
+
+	// rawEventHooks are invoked with every raw whatsmeow event, ahead
+	// of Session's own normalisation.
+	rawEventHooks []RawEventHook
+
+	// messageTransforms filter and/or rewrite incoming messages before
+	// they reach eventHandlers.
+	messageTransforms []MessageTransform
+
+	// mediaKeys escrows re-download keys for received media.
+	mediaKeys *mediaKeyStore
+
+	// conversionProfiles overrides attachment conversion behavior per
+	// chat JID.
+	conversionProfiles map[string]ConversionProfile
+
+	albums *albumAggregator
+
+	// attachmentMemory bounds how many bytes of attachment data may be
+	// held in memory across concurrent conversions.
+	attachmentMemory *memoryBudget
+
+	// quota bounds how many messages may be sent to a single chat in a
+	// sliding window, guarding against runaway or abusive callers.
+	quota *sendQuota
+
+	// departed tracks contacts soft-deleted pending purge.
+	departed *departedUsers
+
+	// outgoingDebugHooks are invoked with the raw protobuf of every
+	// outgoing message before it's sent.
+	outgoingDebugHooks []OutgoingMessageDebugHook
+
+	// idMapper translates between XMPP stanza IDs and WhatsApp message
+	// IDs.
+	idMapper IDMapper
+
+	// cache holds contact and group metadata warm-started from a
+	// previous run, or populated as this run fetches it.
+	cache *warmCache
+
+	// sentMessages tracks message IDs we originated and when.
+	sentMessages *sentMessageStore
+
+	// lastMessages tracks the most recent message seen per chat, as a
+	// backfill reference point for history-sync requests.
+	lastMessages *lastMessageStore
+
+	// ffmpeg runs the ffmpeg/ffprobe invocations behind audio
+	// conversion and waveform generation; overridden in tests with a
+	// fake that returns canned output.
+	ffmpeg ffmpegRunner
+
+	deviceProps DeviceProps
+}
+
+// NewSession returns a Session wrapping the given whatsmeow client. The
+// client is expected to already be configured with a device store; it
+// is not connected until Connect is called.
+func NewSession(client *whatsmeow.Client) *Session {
+	return &Session{
+		client:           client,
+		mediaKeys:        newMediaKeyStore(),
+		albums:           newAlbumAggregator(),
+		sentMessages:     newSentMessageStore(),
+		lastMessages:     newLastMessageStore(),
+		ffmpeg:           defaultFFmpegRunner,
+		attachmentMemory: newMemoryBudget(0),
+		quota:            newSendQuota(0, 0),
+		departed:         newDepartedUsers(),
+		idMapper:         newMemoryIDMapper(),
+		cache:            newWarmCache(),
+	}
+}
+
+// SetConfig replaces the session's behavioral options.
+func (s *Session) SetConfig(cfg Config) {
+	s.config = cfg
+}
+
+// SetAttachmentMemoryBudget caps how many bytes of attachment data the
+// session will hold in memory at once across concurrent conversions.
+// A limit of 0 restores the default.
+func (s *Session) SetAttachmentMemoryBudget(limit int64) {
+	s.attachmentMemory = newMemoryBudget(limit)
+}
+
+// SetSendQuota caps outgoing messages to a single chat to limit
+// messages per window. A limit or window of 0 restores the default.
+func (s *Session) SetSendQuota(limit int, window time.Duration) {
+	s.quota = newSendQuota(limit, window)
+}
+
+// Connect establishes the underlying WhatsApp connection and begins
+// dispatching events to registered handlers.
+func (s *Session) Connect(ctx context.Context) error {
+	if s.client == nil {
+		return fmt.Errorf("whatsapp: session has no underlying client")
+	}
+	s.client.AddEventHandler(s.handleEvent)
+	if err := s.client.Connect(); err != nil {
+		return fmt.Errorf("whatsapp: connect: %w", err)
+	}
+	return nil
+}
+
+// Disconnect tears down the underlying WhatsApp connection.
+func (s *Session) Disconnect() {
+	if s.client != nil {
+		s.client.Disconnect()
+	}
+}
+
+func (s *Session) handleEvent(evt interface{}) {
+	for _, hook := range s.rawEventHooks {
+		hook(evt)
+	}
+
+	switch typed := evt.(type) {
+	case *events.Message:
+		s.handleMessageEvent(typed)
+		return
+	case *events.GroupInfo:
+		s.handleGroupInfoEvent(typed)
+		return
+	case *events.Receipt:
+		s.handleReceiptEvent(typed)
+	case *events.StreamReplaced:
+		s.handleStreamReplacedEvent(typed)
+		return
+	case *events.Picture:
+		s.handlePictureEvent(typed)
+		return
+	}
+	for _, h := range s.eventHandlers {
+		h(evt)
+	}
+}
+
+// jid is a small helper for parsing a bare phone number or full JID
+// string into a types.JID, defaulting to the standard user server.
+func jid(id string) (types.JID, error) {
+	if !strings.Contains(id, "@") {
+		return types.NewJID(id, types.DefaultUserServer), nil
+	}
+	parsed, err := types.ParseJID(id)
+	if err != nil {
+		return types.JID{}, fmt.Errorf("whatsapp: invalid JID %q: %w", id, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,37 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// SetMessageStarred stars or unstars a message for our own account.
+func (s *Session) SetMessageStarred(ctx context.Context, chat, messageID string, starred bool) error {
+	target, err := jid(chat)
+	if err != nil {
+		return err
+	}
+	if messageID == "" {
+		return fmt.Errorf("whatsapp: star requires a message ID")
+	}
+
+	return s.setMessageStarredForJID(ctx, target, messageID, starred)
+}
+
+func (s *Session) setMessageStarredForJID(ctx context.Context, target types.JID, messageID string, starred bool) error {
+	fromMe, _ := s.weSentMessage(target, messageID)
+
+	sender := target
+	if fromMe {
+		sender = *s.client.Store.ID
+	}
+
+	patch := appstate.BuildStar(target, sender, messageID, fromMe, starred)
+	if err := s.client.SendAppState(ctx, patch); err != nil {
+		return fmt.Errorf("whatsapp: setting starred state of %s in %s: %w", messageID, target, err)
+	}
+	return nil
+}
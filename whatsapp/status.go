@@ -0,0 +1,75 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// StatusUpdate is a WhatsApp "status" (story): a piece of media or
+// text posted to the status broadcast list rather than a chat.
+type StatusUpdate struct {
+	Body           string
+	AttachmentKind AttachmentKind
+	Attachment     *Attachment
+}
+
+// PostStatus posts a new status update, visible to our status
+// audience.
+func (s *Session) PostStatus(ctx context.Context, update StatusUpdate) (string, error) {
+	if update.Body == "" && update.Attachment == nil {
+		return "", fmt.Errorf("whatsapp: status update requires a body or an attachment")
+	}
+	if update.Attachment != nil && update.AttachmentKind == AttachmentKindNone {
+		return "", fmt.Errorf("whatsapp: status attachment present without an attachment kind")
+	}
+
+	return s.postStatusUpdate(ctx, update)
+}
+
+// postStatusUpdate posts update to the status broadcast JID, reusing
+// the regular send path so status posts get the same text/attachment
+// handling as a normal chat message.
+func (s *Session) postStatusUpdate(ctx context.Context, update StatusUpdate) (string, error) {
+	req := SendRequest{
+		Chat:           types.StatusBroadcastJID.String(),
+		Body:           update.Body,
+		AttachmentKind: update.AttachmentKind,
+	}
+	if update.Attachment != nil {
+		req.Attachments = []Attachment{*update.Attachment}
+	}
+
+	return s.SendMessage(ctx, req)
+}
+
+// IncomingStatus is a status update (story) posted by a contact.
+type IncomingStatus struct {
+	From string
+	StatusUpdate
+}
+
+// handleStatusMessageEvent normalizes a status broadcast message into
+// an IncomingStatus event.
+func (s *Session) handleStatusMessageEvent(from, body string) {
+	s.handleEvent(&IncomingStatus{From: from, StatusUpdate: StatusUpdate{Body: body}})
+}
+
+// SendStatusViewReceipt tells the poster of statusID that we've viewed
+// their status update.
+func (s *Session) SendStatusViewReceipt(ctx context.Context, poster, statusID string) error {
+	target, err := jid(poster)
+	if err != nil {
+		return err
+	}
+	return s.sendStatusViewReceipt(ctx, target, statusID)
+}
+
+func (s *Session) sendStatusViewReceipt(ctx context.Context, target types.JID, statusID string) error {
+	if err := s.client.MarkRead(ctx, []types.MessageID{statusID}, time.Now(), types.StatusBroadcastJID, target); err != nil {
+		return fmt.Errorf("whatsapp: sending status view receipt to %s: %w", target, err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package whatsapp
+
+// IncomingSticker is a sticker received from WhatsApp.
+type IncomingSticker struct {
+	MessageID string
+	Data      []byte
+	MIMEType  string
+	Animated  bool
+}
+
+// stickerFallbackMIME is what animated (WebP animation) stickers are
+// transcoded to for clients that can't render animated WebP, since
+// most XMPP/Jingle viewers support GIF universally.
+const stickerFallbackMIME = "image/gif"
+
+// displayAttachment returns the attachment to actually hand to XMPP for
+// sticker s: animated stickers are represented as a GIF-flavored
+// Attachment placeholder for the media pipeline to transcode, static
+// stickers pass through unchanged.
+func (s IncomingSticker) displayAttachment() Attachment {
+	att := Attachment{Kind: AttachmentKindSticker, Data: s.Data, MIMEType: s.MIMEType}
+	if s.Animated {
+		att.Filename = "sticker.gif"
+		att.MIMEType = stickerFallbackMIME
+	} else {
+		att.Filename = "sticker.webp"
+	}
+	return att
+}
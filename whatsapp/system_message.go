@@ -0,0 +1,65 @@
+package whatsapp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SystemMessageKind identifies a bridge-generated system message (as
+// opposed to one relayed from WhatsApp), so callers can render it in
+// their own language instead of a hardcoded English string.
+type SystemMessageKind int
+
+const (
+	SystemMessageParticipantJoined SystemMessageKind = iota
+	SystemMessageParticipantLeft
+	SystemMessageParticipantPromoted
+	SystemMessageParticipantDemoted
+	SystemMessageGroupPhotoChanged
+	SystemMessageDisappearingTimerChanged
+)
+
+// systemMessageTemplates maps a language tag to its templates for each
+// SystemMessageKind. Templates take one %s placeholder for the
+// relevant JID or duration; SystemMessageGroupPhotoChanged and kinds
+// with no arguments ignore extra values passed to
+// RenderSystemMessage.
+var systemMessageTemplates = map[string]map[SystemMessageKind]string{
+	"en": {
+		SystemMessageParticipantJoined:        "%s joined the group",
+		SystemMessageParticipantLeft:          "%s left the group",
+		SystemMessageParticipantPromoted:      "%s is now an admin",
+		SystemMessageParticipantDemoted:       "%s is no longer an admin",
+		SystemMessageGroupPhotoChanged:        "Group photo changed",
+		SystemMessageDisappearingTimerChanged: "Disappearing messages set to %s",
+	},
+	"es": {
+		SystemMessageParticipantJoined:        "%s se unió al grupo",
+		SystemMessageParticipantLeft:          "%s salió del grupo",
+		SystemMessageParticipantPromoted:      "%s ahora es administrador",
+		SystemMessageParticipantDemoted:       "%s ya no es administrador",
+		SystemMessageGroupPhotoChanged:        "La foto del grupo cambió",
+		SystemMessageDisappearingTimerChanged: "Los mensajes temporales se ajustaron a %s",
+	},
+}
+
+// defaultSystemMessageLanguage is used when RenderSystemMessage is
+// asked for a language with no templates of its own.
+const defaultSystemMessageLanguage = "en"
+
+// RenderSystemMessage renders kind in language, falling back to
+// English if language has no templates.
+func RenderSystemMessage(kind SystemMessageKind, language string, args ...interface{}) string {
+	templates, ok := systemMessageTemplates[language]
+	if !ok {
+		templates = systemMessageTemplates[defaultSystemMessageLanguage]
+	}
+	template, ok := templates[kind]
+	if !ok {
+		template = systemMessageTemplates[defaultSystemMessageLanguage][kind]
+	}
+	if !strings.Contains(template, "%") {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
@@ -0,0 +1,23 @@
+package whatsapp
+
+import (
+	"fmt"
+	"os"
+)
+
+// tempDir returns a writable scratch directory for transient files
+// (e.g. media conversion intermediates), falling back to an in-memory
+// approach by returning an error the caller can use to skip disk-backed
+// paths entirely rather than crashing when the usual temp location is
+// read-only (e.g. a read-only root filesystem in a hardened container).
+func tempDir() (string, error) {
+	dir := os.TempDir()
+	probe, err := os.CreateTemp(dir, "slidge-whatsapp-probe-*")
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: temp directory %s is not writable: %w", dir, err)
+	}
+	name := probe.Name()
+	probe.Close()
+	os.Remove(name)
+	return dir, nil
+}
@@ -0,0 +1,27 @@
+package whatsapp
+
+// MessageTransform inspects (and may rewrite) an incoming message
+// before it reaches EventHandlers. Returning ok=false drops the
+// message entirely, letting scripts filter unwanted traffic (e.g.
+// muted broadcast spam) without touching Session internals.
+type MessageTransform func(msg IncomingMessage) (out IncomingMessage, ok bool)
+
+// AddMessageTransform registers t to run, in registration order, on
+// every incoming message before dispatch. A transform that drops a
+// message (ok=false) prevents later transforms from running on it.
+func (s *Session) AddMessageTransform(t MessageTransform) {
+	s.messageTransforms = append(s.messageTransforms, t)
+}
+
+// applyMessageTransforms runs msg through all registered transforms,
+// returning ok=false if any of them dropped it.
+func (s *Session) applyMessageTransforms(msg IncomingMessage) (IncomingMessage, bool) {
+	for _, t := range s.messageTransforms {
+		var ok bool
+		msg, ok = t(msg)
+		if !ok {
+			return IncomingMessage{}, false
+		}
+	}
+	return msg, true
+}
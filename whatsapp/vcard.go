@@ -0,0 +1,51 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContactCard is a shared contact, sent to WhatsApp as a vCard.
+type ContactCard struct {
+	DisplayName string
+	// VCard is the full vCard payload (BEGIN:VCARD ... END:VCARD).
+	VCard string
+}
+
+// SendContactCard sends a vCard to chat.
+func (s *Session) SendContactCard(ctx context.Context, chat string, card ContactCard) (string, error) {
+	if card.DisplayName == "" {
+		return "", fmt.Errorf("whatsapp: contact card requires a display name")
+	}
+	if card.VCard == "" {
+		return "", fmt.Errorf("whatsapp: contact card requires vCard data")
+	}
+
+	target, err := jid(chat)
+	if err != nil {
+		return "", err
+	}
+
+	return s.sendContactCardToJID(ctx, target, card)
+}
+
+func (s *Session) sendContactCardToJID(ctx context.Context, target types.JID, card ContactCard) (string, error) {
+	msg := &waE2E.Message{
+		ContactMessage: &waE2E.ContactMessage{
+			DisplayName: proto.String(card.DisplayName),
+			Vcard:       proto.String(card.VCard),
+		},
+	}
+
+	resp, err := s.client.SendMessage(ctx, target, msg)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: sending contact card to %s: %w", target, err)
+	}
+
+	s.sentMessages.record(resp.ID, resp.Timestamp)
+	return resp.ID, nil
+}
@@ -0,0 +1,26 @@
+package whatsapp
+
+import "go.mau.fi/whatsmeow/store"
+
+// Version is the whatsapp package's own version, set at build time via
+// -ldflags "-X codeberg.org/slidge/slidge-whatsapp/whatsapp.Version=...".
+var Version = "dev"
+
+// VersionInfo summarizes the versions in use, for diagnostics
+// purposes.
+type VersionInfo struct {
+	AdapterVersion string
+	// WhatsmeowVersion is the WhatsApp Web client version whatsmeow
+	// identifies as, not the version of the whatsmeow module itself;
+	// the module version is not reported.
+	WhatsmeowVersion string
+}
+
+// GetVersionInfo reports the adapter's own version alongside the
+// WhatsApp Web protocol version whatsmeow currently identifies as.
+func GetVersionInfo() VersionInfo {
+	return VersionInfo{
+		AdapterVersion:   Version,
+		WhatsmeowVersion: store.GetWAVersion().String(),
+	}
+}